@@ -0,0 +1,154 @@
+// Anytype account id encoding.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"crypto/ed25519"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crc16"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/any-sync/util/strkey"
+	"github.com/mr-tron/base58"
+)
+
+// Account ids are strkey encoded:
+//
+//	Base58(0x5b || pubkey[32] || crc16_xmodem_le[2])
+//
+// The version byte 0x5b makes every id start with 'A'.
+const (
+	accountVersionByte = byte(strkey.AccountAddressVersionByte)
+	accountChecksumLen = 2
+	accountRawLen      = 1 + ed25519.PublicKeySize + accountChecksumLen
+)
+
+var (
+	// ErrAccountMismatch is returned when an account id does not belong to a key.
+	ErrAccountMismatch = errors.New("account id does not match public key")
+	// ErrBadAccountEncoding is returned when an account id is not valid base58
+	// (or base32, for the multibase form) or its checksum does not match.
+	ErrBadAccountEncoding = fmt.Errorf("%w: account id is malformed or fails its checksum", ErrEncoding)
+	// ErrBadAccountLength is returned when an account id does not decode to a
+	// version byte, a 32-byte Ed25519 public key and a 2-byte checksum.
+	ErrBadAccountLength = fmt.Errorf("%w: account id has the wrong length", ErrEncoding)
+	// ErrBadAccountPrefix is returned when an account id's version byte is not 0x5b.
+	ErrBadAccountPrefix = fmt.Errorf("%w: account id version byte is not 0x5b", ErrEncoding)
+)
+
+// AccountBinding pairs an account id with the public key a peer claims for it.
+type AccountBinding struct {
+	AccountID string
+	PubKey    crypto.PubKey
+}
+
+// PubKeyToAccount returns the Anytype account id for pk. Account ids are only
+// defined for Ed25519 keys; other key types fail with ErrUnsupportedKeyType.
+func PubKeyToAccount(pk crypto.PubKey) (string, error) {
+	if err := checkEd25519PubKey("account id", pk); err != nil {
+		return "", err
+	}
+	return pk.Account(), nil
+}
+
+// checkEd25519PubKey returns an error unless pk is an Ed25519 public key. Other
+// key types implement crypto.PubKey too (Secp256k1PubKey returns "" from
+// Account), so helpers that encode a key as an account id or did:key check
+// the type rather than trust the interface.
+func checkEd25519PubKey(op string, pk crypto.PubKey) error {
+	switch pk.(type) {
+	case nil:
+		return fmt.Errorf("%s: nil public key", op)
+	case *crypto.Ed25519PubKey:
+		return nil
+	default:
+		return fmt.Errorf("%w: %s of %T", ErrUnsupportedKeyType, op, pk)
+	}
+}
+
+// AccountToPubKey parses an Anytype account id and returns its Ed25519 public key.
+// The version byte, payload length and checksum are all verified, and failures
+// are reported as by ValidateAccountID.
+func AccountToPubKey(accountID string) (crypto.PubKey, error) {
+	raw, err := base58.Decode(accountID)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadAccountEncoding, err)
+	}
+	return accountPayloadToPubKey(raw)
+}
+
+// ValidateAccountID checks that id is a well-formed account id without
+// returning its key, for validating user input as it is entered. A typo
+// almost always fails the checksum. Errors match ErrBadAccountEncoding when
+// id is not base58 or its checksum fails, ErrBadAccountLength when it does not
+// decode to a version byte, a 32-byte Ed25519 key and a checksum, and
+// ErrBadAccountPrefix when the version byte is not 0x5b. All three match
+// ErrEncoding.
+func ValidateAccountID(id string) error {
+	_, err := AccountToPubKey(id)
+	return err
+}
+
+// accountPayloadToPubKey checks a decoded 0x5b || pubkey || crc16 payload and
+// returns its public key.
+func accountPayloadToPubKey(raw []byte) (crypto.PubKey, error) {
+	if len(raw) != accountRawLen {
+		return nil, fmt.Errorf("%w: decodes to %d bytes, want %d", ErrBadAccountLength, len(raw), accountRawLen)
+	}
+	if raw[0] != accountVersionByte {
+		return nil, fmt.Errorf("%w: got 0x%02x", ErrBadAccountPrefix, raw[0])
+	}
+	body, checksum := raw[:len(raw)-accountChecksumLen], raw[len(raw)-accountChecksumLen:]
+	if err := crc16.Validate(body, checksum); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadAccountEncoding, err)
+	}
+	pk, err := crypto.UnmarshalEd25519PublicKey(body[1:])
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrBadAccountLength, err)
+	}
+	return pk, nil
+}
+
+// VerifyAccountBinding checks that accountID is the account id of pk. The ids
+// are compared in constant time so a timing probe cannot learn how long a
+// prefix of a forged id matched. pk must be an Ed25519 key; other key types
+// fail with ErrUnsupportedKeyType.
+func VerifyAccountBinding(accountID string, pk crypto.PubKey) error {
+	if err := checkEd25519PubKey("verify account binding", pk); err != nil {
+		return err
+	}
+	if subtle.ConstantTimeCompare([]byte(pk.Account()), []byte(accountID)) != 1 {
+		return ErrAccountMismatch
+	}
+	return nil
+}
+
+// VerifyAccountBindings checks every pair and returns one result per pair,
+// nil for the pairs that match. A bad pair does not stop the others being checked.
+func VerifyAccountBindings(pairs []AccountBinding) []error {
+	errs := make([]error, len(pairs))
+	for i, p := range pairs {
+		errs[i] = VerifyAccountBinding(p.AccountID, p.PubKey)
+	}
+	return errs
+}
+
+// ConfirmAccount derives the account at index from m and checks that its
+// account id is expectedAccountID, for import flows that confirm a phrase
+// against an id shown elsewhere. The ids are compared with VerifyAccountBinding,
+// in constant time; a different account fails with ErrAccountMismatch. The
+// derived keys are wiped before returning.
+func ConfirmAccount(m crypto.Mnemonic, index int, expectedAccountID string) error {
+	res, err := DeriveKeysContext(context.Background(), m, index)
+	if err != nil {
+		return err
+	}
+	defer res.Wipe()
+	return VerifyAccountBinding(expectedAccountID, res.Identity().GetPublic())
+}
@@ -0,0 +1,191 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+const refAccountID = "A9ZJ9CkjFnMLw8Lsgt8gnVTBqhrx1fRPbdCSucdpXxVi78WW"
+
+func TestAccountToPubKeyRoundTrip(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := AccountToPubKey(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pk.Equals(res.Identity().GetPublic()) {
+		t.Fatal("decoded public key does not match the derived identity")
+	}
+	if got, err := PubKeyToAccount(pk); err != nil || got != refAccountID {
+		t.Fatalf("PubKeyToAccount() = %s, %v, want %s", got, err, refAccountID)
+	}
+}
+
+func TestAccountToPubKeyRejectsMalformed(t *testing.T) {
+	raw, err := base58.Decode(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wrongVersion := append([]byte{0xd3}, raw[1:]...)
+	badChecksum := append([]byte(nil), raw...)
+	badChecksum[len(badChecksum)-1] ^= 0xff
+
+	tests := []struct {
+		name string
+		id   string
+	}{
+		{"empty", ""},
+		{"not base58", "A9ZJ9CkjFnMLw8Lsgt8gnVTBqhrx1fRPbdCSucdpXxVi78W0"},
+		{"truncated", base58.Encode(raw[:len(raw)-3])},
+		{"wrong version", base58.Encode(wrongVersion)},
+		{"bad checksum", base58.Encode(badChecksum)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := AccountToPubKey(tt.id); err == nil {
+				t.Fatalf("AccountToPubKey(%q) succeeded, want error", tt.id)
+			}
+		})
+	}
+}
+
+func TestVerifyAccountBindings(t *testing.T) {
+	results, err := DeriveAccountRange(crypto.Mnemonic(refMnemonic), 0, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk0 := results[0].Identity().GetPublic()
+	pk1 := results[1].Identity().GetPublic()
+
+	errs := VerifyAccountBindings([]AccountBinding{
+		{AccountID: refAccountID, PubKey: pk0},
+		{AccountID: refAccountID, PubKey: pk1},
+		{AccountID: results[1].AccountID, PubKey: pk1},
+		{AccountID: "", PubKey: pk0},
+		{AccountID: refAccountID, PubKey: nil},
+	})
+	if len(errs) != 5 {
+		t.Fatalf("got %d results, want 5", len(errs))
+	}
+	if errs[0] != nil || errs[2] != nil {
+		t.Fatalf("valid bindings rejected: %v, %v", errs[0], errs[2])
+	}
+	for _, i := range []int{1, 3} {
+		if !errors.Is(errs[i], ErrAccountMismatch) {
+			t.Fatalf("errs[%d] = %v, want %v", i, errs[i], ErrAccountMismatch)
+		}
+	}
+	if errs[4] == nil {
+		t.Fatal("nil public key accepted")
+	}
+}
+
+func TestConfirmAccount(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	if err := ConfirmAccount(m, 0, refAccountID); err != nil {
+		t.Fatalf("ConfirmAccount(match) = %v", err)
+	}
+
+	other, err := DeriveKeys(m, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name     string
+		m        crypto.Mnemonic
+		index    int
+		expected string
+		want     error
+	}{
+		{"other account id", m, 0, other.AccountID, ErrAccountMismatch},
+		{"other index", m, 1, refAccountID, ErrAccountMismatch},
+		{"truncated id", m, 0, refAccountID[:len(refAccountID)-1], ErrAccountMismatch},
+		{"invalid phrase", "tag volcano", 0, refAccountID, ErrInvalidMnemonic},
+		{"invalid index", m, -1, refAccountID, ErrInvalidIndex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := ConfirmAccount(tt.m, tt.index, tt.expected); !errors.Is(err, tt.want) {
+				t.Fatalf("ConfirmAccount() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateAccountID(t *testing.T) {
+	if err := ValidateAccountID(refAccountID); err != nil {
+		t.Fatalf("ValidateAccountID(valid) = %v", err)
+	}
+
+	raw, err := base58.Decode(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Replace one character with another base58 character, as a typo would.
+	typo := []byte(refAccountID)
+	if typo[10] == 'x' {
+		typo[10] = 'y'
+	} else {
+		typo[10] = 'x'
+	}
+	wrongVersion := append([]byte{0xd3}, raw[1:]...)
+
+	tests := []struct {
+		name string
+		id   string
+		want error
+	}{
+		{"one character changed", string(typo), ErrBadAccountEncoding},
+		{"not base58", refAccountID[:len(refAccountID)-1] + "0", ErrBadAccountEncoding},
+		{"empty", "", ErrBadAccountEncoding},
+		{"too short", base58.Encode(raw[:len(raw)-1]), ErrBadAccountLength},
+		{"too long", base58.Encode(append(append([]byte(nil), raw...), 0x00)), ErrBadAccountLength},
+		{"wrong version", base58.Encode(wrongVersion), ErrBadAccountPrefix},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateAccountID(tt.id)
+			if !errors.Is(err, tt.want) || !errors.Is(err, ErrEncoding) {
+				t.Fatalf("ValidateAccountID(%q) = %v, want %v", tt.id, err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAccountHelpersRejectSecp256k1(t *testing.T) {
+	key, err := NewSecp256k1PrivKey(mustHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk := key.GetPublic()
+	tests := []struct {
+		name string
+		call func() error
+	}{
+		{"PubKeyToAccount", func() error { _, err := PubKeyToAccount(pk); return err }},
+		{"VerifyAccountBinding", func() error { return VerifyAccountBinding("", pk) }},
+		{"EncodeAccountID default", func() error { _, err := EncodeAccountID(pk, EncodingDefault); return err }},
+		{"EncodeAccountID base58", func() error { _, err := EncodeAccountID(pk, EncodingBase58); return err }},
+		{"EncodeAccountID base32", func() error { _, err := EncodeAccountID(pk, EncodingBase32); return err }},
+		{"PubKeyToDIDKey", func() error { _, err := PubKeyToDIDKey(pk); return err }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, ErrUnsupportedKeyType) {
+				t.Fatalf("%s(secp256k1) = %v, want %v", tt.name, err, ErrUnsupportedKeyType)
+			}
+		})
+	}
+	if err := VerifyAccountBinding(refAccountID, nil); err == nil {
+		t.Fatal("VerifyAccountBinding(nil) succeeded")
+	}
+}
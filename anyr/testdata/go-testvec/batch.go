@@ -0,0 +1,75 @@
+// Parallel derivation of many accounts.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"runtime"
+	"sync"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// DeriveRequest is one item of a DeriveBatch call.
+type DeriveRequest struct {
+	Mnemonic crypto.Mnemonic
+	Index    int
+}
+
+// DeriveResult is the outcome of one DeriveRequest: Keys on success, or Err
+// as DeriveKeysContext would return it.
+type DeriveResult struct {
+	Keys *KeyResult
+	Err  error
+}
+
+// DeriveBatch derives the keys for every request on a pool of workers and
+// returns the results in request order. A bad phrase or index fails only its
+// own item. workers below 1 means runtime.GOMAXPROCS(0).
+//
+// ctx is checked before each item starts. Cancelling it stops the PBKDF2
+// rounds of in-flight items and skips the rest; those items carry
+// ErrDerivation wrapping ctx.Err(), and DeriveBatch returns that error
+// alongside the partial results. If every item had already finished when ctx
+// was cancelled, the results are complete and the error is nil.
+func DeriveBatch(ctx context.Context, reqs []DeriveRequest, workers int) ([]DeriveResult, error) {
+	if workers < 1 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	workers = min(workers, len(reqs))
+
+	results := make([]DeriveResult, len(reqs))
+	next := make(chan int)
+	var wg sync.WaitGroup
+	wg.Add(workers)
+	for range workers {
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				if err := ctx.Err(); err != nil {
+					results[i].Err = fmt.Errorf("%w: %w", ErrDerivation, err)
+					continue
+				}
+				res, err := DeriveKeysContext(ctx, reqs[i].Mnemonic, reqs[i].Index)
+				results[i] = DeriveResult{Keys: res, Err: err}
+			}
+		}()
+	}
+	for i := range reqs {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, r := range results {
+		if r.Err != nil && (errors.Is(r.Err, context.Canceled) || errors.Is(r.Err, context.DeadlineExceeded)) {
+			return results, r.Err
+		}
+	}
+	return results, nil
+}
@@ -0,0 +1,148 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func batchRequests(n int) []DeriveRequest {
+	reqs := make([]DeriveRequest, n)
+	for i := range reqs {
+		reqs[i] = DeriveRequest{Mnemonic: crypto.Mnemonic(refMnemonic), Index: i}
+	}
+	return reqs
+}
+
+func TestDeriveBatch(t *testing.T) {
+	reqs := batchRequests(6)
+	reqs[2].Mnemonic = "not a phrase"
+	reqs[4].Index = -1
+
+	results, err := DeriveBatch(context.Background(), reqs, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != len(reqs) {
+		t.Fatalf("got %d results, want %d", len(results), len(reqs))
+	}
+	for i, res := range results {
+		switch i {
+		case 2:
+			if !errors.Is(res.Err, ErrInvalidMnemonic) {
+				t.Fatalf("results[2].Err = %v, want %v", res.Err, ErrInvalidMnemonic)
+			}
+		case 4:
+			if !errors.Is(res.Err, ErrInvalidIndex) {
+				t.Fatalf("results[4].Err = %v, want %v", res.Err, ErrInvalidIndex)
+			}
+		default:
+			if res.Err != nil {
+				t.Fatalf("results[%d].Err = %v", i, res.Err)
+			}
+			want, err := DeriveKeys(reqs[i].Mnemonic, uint32(reqs[i].Index))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if res.Keys.AccountID != want.AccountID || res.Keys.Index != uint32(i) {
+				t.Fatalf("results[%d] is not account %d", i, i)
+			}
+		}
+	}
+}
+
+func TestDeriveBatchConcurrent(t *testing.T) {
+	// Run under -race: several batches share the package logger and wordlist.
+	done := make(chan error)
+	for range 4 {
+		go func() {
+			_, err := DeriveBatch(context.Background(), batchRequests(4), 0)
+			done <- err
+		}()
+	}
+	for range 4 {
+		if err := <-done; err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestDeriveBatchCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := DeriveBatch(ctx, batchRequests(5), 2)
+	if !errors.Is(err, ErrDerivation) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeriveBatch() = %v, want %v and %v", err, ErrDerivation, context.Canceled)
+	}
+	for i, res := range results {
+		if !errors.Is(res.Err, context.Canceled) || res.Keys != nil {
+			t.Fatalf("results[%d] = %+v, want a cancellation error", i, res)
+		}
+	}
+}
+
+// cancelAfterLogger cancels a context once it has seen n successful derivations.
+type cancelAfterLogger struct {
+	nopLogger
+	n      int
+	cancel context.CancelFunc
+}
+
+func (l *cancelAfterLogger) Debug(string, ...any) {
+	if l.n--; l.n == 0 {
+		l.cancel()
+	}
+}
+
+func TestDeriveBatchCancelledAfterCompletion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	reqs := batchRequests(2)
+	// The last derivation cancels ctx as it finishes, before DeriveBatch returns.
+	SetLogger(&cancelAfterLogger{n: len(reqs), cancel: cancel})
+	t.Cleanup(func() { SetLogger(nil) })
+	results, err := DeriveBatch(ctx, reqs, 1)
+	if err != nil {
+		t.Fatalf("DeriveBatch() = %v, want the completed results", err)
+	}
+	if ctx.Err() == nil {
+		t.Fatal("context was not cancelled")
+	}
+	for i, res := range results {
+		if res.Err != nil || res.Keys == nil {
+			t.Fatalf("results[%d] = %+v, want keys", i, res)
+		}
+	}
+}
+
+func TestDeriveBatchEmpty(t *testing.T) {
+	results, err := DeriveBatch(context.Background(), nil, 4)
+	if err != nil || len(results) != 0 {
+		t.Fatalf("DeriveBatch(nil) = %v, %v", results, err)
+	}
+}
+
+func BenchmarkDeriveBatch(b *testing.B) {
+	reqs := batchRequests(8)
+	b.Run("serial", func(b *testing.B) {
+		for b.Loop() {
+			for _, r := range reqs {
+				if _, err := DeriveKeysContext(context.Background(), r.Mnemonic, r.Index); err != nil {
+					b.Fatal(err)
+				}
+			}
+		}
+	})
+	b.Run("parallel", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := DeriveBatch(context.Background(), reqs, 0); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
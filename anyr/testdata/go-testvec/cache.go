@@ -0,0 +1,138 @@
+// In-memory cache of derived account keys.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"container/list"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"sync"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// cacheKey identifies a cache entry: SHA-256 of the per-cache salt, the
+// mnemonic and the index. The mnemonic itself is never stored.
+type cacheKey [sha256.Size]byte
+
+type cacheEntry struct {
+	key cacheKey
+	res *KeyResult
+}
+
+// DerivationCache caches DeriveKeys results so repeated requests for the same
+// account skip PBKDF2. Entries are evicted least recently used first, and the
+// private keys of an evicted entry are wiped. It is safe for concurrent use.
+type DerivationCache struct {
+	maxSize int
+	salt    [32]byte
+
+	mu      sync.Mutex
+	order   *list.List // of *cacheEntry, most recently used first
+	entries map[cacheKey]*list.Element
+}
+
+// NewDerivationCache returns a cache holding at most maxSize results. A
+// maxSize below 1 is treated as 1.
+func NewDerivationCache(maxSize int) *DerivationCache {
+	c := &DerivationCache{
+		maxSize: max(maxSize, 1),
+		order:   list.New(),
+		entries: make(map[cacheKey]*list.Element),
+	}
+	rand.Read(c.salt[:])
+	return c
+}
+
+// GetOrDerive returns the keys for m and index, deriving and caching them on a
+// miss. The result is a copy owned by the caller: the cache never wipes it,
+// and the caller may Wipe it without affecting the cache. Phrases are not
+// normalized, so differently spaced copies of a phrase are separate entries.
+func (c *DerivationCache) GetOrDerive(m crypto.Mnemonic, index int) (*KeyResult, error) {
+	if err := checkIndex(index); err != nil {
+		return nil, err
+	}
+	key := c.key(m, index)
+	if res := c.get(key); res != nil {
+		return res, nil
+	}
+
+	// Derive without holding the lock; concurrent misses for one key may both
+	// derive, and the loser's result is wiped.
+	res, err := DeriveKeysContext(context.Background(), m, index)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Wipe()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if el, ok := c.entries[key]; ok {
+		c.order.MoveToFront(el)
+		return el.Value.(*cacheEntry).res.clone(), nil
+	}
+	cached := res.clone()
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, res: cached})
+	for c.order.Len() > c.maxSize {
+		c.evict(c.order.Back())
+	}
+	return cached.clone(), nil
+}
+
+// Len returns the number of cached results.
+func (c *DerivationCache) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.order.Len()
+}
+
+// Purge wipes and removes every cached result.
+func (c *DerivationCache) Purge() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for c.order.Len() > 0 {
+		c.evict(c.order.Back())
+	}
+}
+
+func (c *DerivationCache) get(key cacheKey) *KeyResult {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	el, ok := c.entries[key]
+	if !ok {
+		return nil
+	}
+	c.order.MoveToFront(el)
+	return el.Value.(*cacheEntry).res.clone()
+}
+
+// evict removes el and wipes its result. c.mu must be held.
+func (c *DerivationCache) evict(el *list.Element) {
+	e := c.order.Remove(el).(*cacheEntry)
+	delete(c.entries, e.key)
+	e.res.Wipe()
+}
+
+func (c *DerivationCache) key(m crypto.Mnemonic, index int) cacheKey {
+	h := sha256.New()
+	h.Write(c.salt[:])
+	h.Write(binary.BigEndian.AppendUint32(nil, uint32(index)))
+	h.Write([]byte(m))
+	var k cacheKey
+	h.Sum(k[:0])
+	return k
+}
+
+// clone copies r into buffers owned by the copy, without repeating PBKDF2.
+func (r *KeyResult) clone() *KeyResult {
+	node := &extendedKey{
+		key:       append([]byte(nil), r.masterNode.node.key...),
+		chainCode: append([]byte(nil), r.masterNode.node.chainCode...),
+	}
+	return newKeyResult(r.Index, node, append([]byte(nil), r.oldAccountKey.Reveal()...))
+}
@@ -0,0 +1,136 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestDerivationCacheLRU(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	c := NewDerivationCache(2)
+
+	first, err := c.GetOrDerive(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if first.AccountID != refAccountID {
+		t.Fatalf("account id = %s, want %s", first.AccountID, refAccountID)
+	}
+	cached := c.entries[c.key(m, 0)].Value.(*cacheEntry).res
+
+	// A hit returns an independent copy of the same keys.
+	again, err := c.GetOrDerive(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !again.Identity().Equals(first.Identity()) || again.MasterNode().node == first.MasterNode().node {
+		t.Fatal("cache hit is not an independent copy of the cached keys")
+	}
+	again.Wipe()
+	if raw, _ := cached.Identity().Raw(); bytes.Equal(raw, make([]byte, len(raw))) {
+		t.Fatal("wiping a returned result wiped the cached entry")
+	}
+
+	for _, index := range []int{1, 0, 2} {
+		if _, err := c.GetOrDerive(m, index); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// Index 1 was least recently used when index 2 was added.
+	if c.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", c.Len())
+	}
+	if _, ok := c.entries[c.key(m, 1)]; ok {
+		t.Fatal("index 1 was not evicted")
+	}
+	if _, ok := c.entries[c.key(m, 0)]; !ok {
+		t.Fatal("index 0 was evicted")
+	}
+
+	c.Purge()
+	if c.Len() != 0 {
+		t.Fatalf("Len() after Purge = %d, want 0", c.Len())
+	}
+	for name, key := range map[string]crypto.PrivKey{"MasterKey": cached.MasterKey(), "Identity": cached.Identity(), "OldAccountKey": cached.OldAccountKey()} {
+		if raw, _ := key.Raw(); !bytes.Equal(raw, make([]byte, len(raw))) {
+			t.Fatalf("evicted %s not wiped", name)
+		}
+	}
+	if first.AccountID != refAccountID || first.Identity().GetPublic().Account() != refAccountID {
+		t.Fatal("eviction changed a result already returned to the caller")
+	}
+}
+
+func TestDerivationCacheKeyOmitsMnemonic(t *testing.T) {
+	c := NewDerivationCache(1)
+	other := NewDerivationCache(1)
+	key := c.key(crypto.Mnemonic(refMnemonic), 0)
+	if key == other.key(crypto.Mnemonic(refMnemonic), 0) {
+		t.Fatal("caches share a salt")
+	}
+	if key == c.key(crypto.Mnemonic(refMnemonic), 1) {
+		t.Fatal("index is not part of the key")
+	}
+	if strings.Contains(string(key[:]), "volcano") {
+		t.Fatal("cache key contains the mnemonic")
+	}
+}
+
+func TestDerivationCacheConcurrent(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	c := NewDerivationCache(2)
+	var wg sync.WaitGroup
+	errs := make(chan error, 32)
+	for i := range 32 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			res, err := c.GetOrDerive(m, i%3)
+			if err != nil {
+				errs <- err
+				return
+			}
+			if res.Index != uint32(i%3) || res.Identity().GetPublic().Account() != res.AccountID {
+				t.Errorf("goroutine %d: inconsistent result for index %d", i, res.Index)
+			}
+			res.Wipe()
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		t.Fatal(err)
+	}
+	if n := c.Len(); n > 2 {
+		t.Fatalf("Len() = %d exceeds max size 2", n)
+	}
+}
+
+func BenchmarkDeriveKeys(b *testing.B) {
+	m := crypto.Mnemonic(refMnemonic)
+	for b.Loop() {
+		if _, err := DeriveKeys(m, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
+
+func BenchmarkDerivationCacheHit(b *testing.B) {
+	m := crypto.Mnemonic(refMnemonic)
+	c := NewDerivationCache(1)
+	if _, err := c.GetOrDerive(m, 0); err != nil {
+		b.Fatal(err)
+	}
+	for b.Loop() {
+		if _, err := c.GetOrDerive(m, 0); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
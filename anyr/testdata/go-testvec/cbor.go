@@ -0,0 +1,297 @@
+// Deterministic CBOR encodings of TestVector and KeyResult.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"math"
+	"slices"
+)
+
+// CBOR major types used by the encodings.
+const (
+	cborUnsigned = 0
+	cborNegative = 1
+	cborText     = 3
+	cborMap      = 5
+)
+
+// cborField is one map entry: a text key with a text or integer value.
+type cborField struct {
+	key    string
+	text   string
+	int    int64
+	isText bool
+}
+
+func cborTextField(key, value string) cborField {
+	return cborField{key: key, text: value, isText: true}
+}
+
+func cborIntField(key string, value int64) cborField {
+	return cborField{key: key, int: value}
+}
+
+// MarshalCBOR encodes the vector as a CBOR map with the same keys and values
+// as its JSON form. The encoding is deterministic in the sense of RFC 8949
+// section 4.2.1: shortest-form lengths and integers, no indefinite lengths,
+// and keys sorted by their encoded bytes, so equal vectors encode to
+// identical bytes.
+func (v TestVector) MarshalCBOR() ([]byte, error) {
+	return cborEncodeMap(nil, []cborField{
+		cborTextField("account_id", v.AccountID),
+		cborTextField("account_key", v.AccountKey),
+		cborIntField("index", int64(v.Index)),
+		cborTextField("mnemonic", v.Mnemonic),
+		cborTextField("signing_pubkey", v.SigningPubKey),
+	}), nil
+}
+
+// UnmarshalCBOR decodes a vector written by MarshalCBOR. Unknown keys are
+// ignored; every field of MarshalCBOR is required.
+func (v *TestVector) UnmarshalCBOR(data []byte) error {
+	d := cborDecoder{data: data}
+	var out TestVector
+	var seen uint
+	for range d.mapLen() {
+		switch d.text() {
+		case "account_id":
+			d.mark(&seen, 0)
+			out.AccountID = d.text()
+		case "account_key":
+			d.mark(&seen, 1)
+			out.AccountKey = d.text()
+		case "index":
+			d.mark(&seen, 2)
+			index := d.int()
+			if index < math.MinInt || index > math.MaxInt {
+				d.fail("index out of range")
+			}
+			out.Index = int(index)
+		case "mnemonic":
+			d.mark(&seen, 3)
+			out.Mnemonic = d.text()
+		case "signing_pubkey":
+			d.mark(&seen, 4)
+			out.SigningPubKey = d.text()
+		default:
+			d.skip()
+		}
+	}
+	if err := d.finish(seen, 5); err != nil {
+		return err
+	}
+	*v = out
+	return nil
+}
+
+// MarshalCBOR encodes the same fields as ExportJSON as a deterministic CBOR
+// map; see TestVector.MarshalCBOR. Like ExportJSON, the result contains the
+// keys.
+func (r *KeyResult) MarshalCBOR() ([]byte, error) {
+	f, err := r.fields()
+	if err != nil {
+		return nil, err
+	}
+	return cborEncodeMap(nil, []cborField{
+		cborTextField("account_id", f.AccountID),
+		cborTextField("account_key", f.AccountKey),
+		cborIntField("index", int64(f.Index)),
+		cborTextField("old_account_key", f.OldAccountKey),
+	}), nil
+}
+
+// UnmarshalCBOR restores a result written by MarshalCBOR, with the checks of
+// UnmarshalJSON.
+func (r *KeyResult) UnmarshalCBOR(data []byte) error {
+	d := cborDecoder{data: data}
+	var f keyResultFields
+	var seen uint
+	for range d.mapLen() {
+		switch d.text() {
+		case "account_id":
+			d.mark(&seen, 0)
+			f.AccountID = d.text()
+		case "account_key":
+			d.mark(&seen, 1)
+			f.AccountKey = d.text()
+		case "index":
+			d.mark(&seen, 2)
+			index := d.int()
+			if index < 0 || index > math.MaxUint32 {
+				d.fail("index out of range")
+			}
+			f.Index = uint32(index)
+		case "old_account_key":
+			d.mark(&seen, 3)
+			f.OldAccountKey = d.text()
+		default:
+			d.skip()
+		}
+	}
+	if err := d.finish(seen, keyResultFieldCount); err != nil {
+		return err
+	}
+	res, err := f.keyResult()
+	if err != nil {
+		return err
+	}
+	*r = *res
+	return nil
+}
+
+// cborEncodeMap appends fields as a map with keys in deterministic order.
+func cborEncodeMap(b []byte, fields []cborField) []byte {
+	type entry struct{ key, value []byte }
+	entries := make([]entry, len(fields))
+	for i, f := range fields {
+		entries[i].key = cborHead(nil, cborText, uint64(len(f.key)))
+		entries[i].key = append(entries[i].key, f.key...)
+		switch {
+		case f.isText:
+			entries[i].value = append(cborHead(nil, cborText, uint64(len(f.text))), f.text...)
+		case f.int < 0:
+			entries[i].value = cborHead(nil, cborNegative, uint64(-(f.int + 1)))
+		default:
+			entries[i].value = cborHead(nil, cborUnsigned, uint64(f.int))
+		}
+	}
+	slices.SortFunc(entries, func(a, b entry) int { return bytes.Compare(a.key, b.key) })
+
+	b = cborHead(b, cborMap, uint64(len(entries)))
+	for _, e := range entries {
+		b = append(append(b, e.key...), e.value...)
+	}
+	return b
+}
+
+// cborHead appends the shortest head for major type major and argument n.
+func cborHead(b []byte, major byte, n uint64) []byte {
+	m := major << 5
+	switch {
+	case n < 24:
+		return append(b, m|byte(n))
+	case n <= math.MaxUint8:
+		return append(b, m|24, byte(n))
+	case n <= math.MaxUint16:
+		return binary.BigEndian.AppendUint16(append(b, m|25), uint16(n))
+	case n <= math.MaxUint32:
+		return binary.BigEndian.AppendUint32(append(b, m|26), uint32(n))
+	default:
+		return binary.BigEndian.AppendUint64(append(b, m|27), n)
+	}
+}
+
+// cborDecoder reads the subset of CBOR that the MarshalCBOR methods write:
+// definite-length maps, text strings and integers. The first error sticks.
+type cborDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *cborDecoder) fail(msg string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: cbor: %s", ErrEncoding, msg)
+	}
+	d.data = nil
+}
+
+func (d *cborDecoder) next(n uint64) []byte {
+	if uint64(len(d.data)) < n {
+		d.fail("unexpected end of data")
+		return make([]byte, 8)
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+// head reads an item head and returns its major type and argument.
+func (d *cborDecoder) head() (byte, uint64) {
+	c := d.next(1)[0]
+	major, info := c>>5, c&0x1f
+	switch {
+	case info < 24:
+		return major, uint64(info)
+	case info == 24:
+		return major, uint64(d.next(1)[0])
+	case info == 25:
+		return major, uint64(binary.BigEndian.Uint16(d.next(2)))
+	case info == 26:
+		return major, uint64(binary.BigEndian.Uint32(d.next(4)))
+	case info == 27:
+		return major, binary.BigEndian.Uint64(d.next(8))
+	default:
+		d.fail("unsupported additional information")
+		return major, 0
+	}
+}
+
+func (d *cborDecoder) mapLen() int {
+	major, n := d.head()
+	if major != cborMap {
+		d.fail("expected map")
+		return 0
+	}
+	// Each entry takes at least two bytes, which bounds n by the input.
+	if n > uint64(len(d.data))/2 {
+		d.fail("map longer than data")
+		return 0
+	}
+	return int(n)
+}
+
+func (d *cborDecoder) text() string {
+	major, n := d.head()
+	if major != cborText {
+		d.fail("expected text string")
+		return ""
+	}
+	return string(d.next(n))
+}
+
+func (d *cborDecoder) int() int64 {
+	major, n := d.head()
+	switch {
+	case major == cborUnsigned && n <= math.MaxInt64:
+		return int64(n)
+	case major == cborNegative && n <= math.MaxInt64:
+		return -1 - int64(n)
+	default:
+		d.fail("expected 64-bit integer")
+		return 0
+	}
+}
+
+// skip discards a text string or integer value.
+func (d *cborDecoder) skip() {
+	if len(d.data) > 0 && d.data[0]>>5 == cborText {
+		d.text()
+		return
+	}
+	d.int()
+}
+
+// mark records that field number bit was read, failing if it was read before.
+func (d *cborDecoder) mark(seen *uint, bit uint) {
+	if *seen&(1<<bit) != 0 {
+		d.fail("repeated field")
+	}
+	*seen |= 1 << bit
+}
+
+// finish checks for trailing bytes and that fields 0..want-1 were all read.
+func (d *cborDecoder) finish(seen uint, want int) error {
+	if d.err == nil && len(d.data) != 0 {
+		d.fail("trailing bytes")
+	}
+	if d.err == nil && seen != 1<<want-1 {
+		d.fail("missing fields")
+	}
+	return d.err
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestTestVectorCBORRoundTrip(t *testing.T) {
+	vectors, err := GenerateTestVectors([]string{refMnemonic}, []int{0, 300})
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range vectors {
+		data, err := want.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		again, err := want.MarshalCBOR()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, again) {
+			t.Fatal("two encodings of one vector differ")
+		}
+		var got TestVector
+		if err := got.UnmarshalCBOR(data); err != nil {
+			t.Fatal(err)
+		}
+		if got != want {
+			t.Fatalf("round trip = %+v, want %+v", got, want)
+		}
+	}
+}
+
+func TestTestVectorCBORLayout(t *testing.T) {
+	v := TestVector{AccountID: "a", AccountKey: "b", Index: -1, Mnemonic: "m", SigningPubKey: "s"}
+	data, err := v.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Keys sort by encoded bytes, so shorter keys come first.
+	want := "a5" +
+		"65696e646578" + "20" +
+		"686d6e656d6f6e6963" + "616d" +
+		"6a6163636f756e745f6964" + "6161" +
+		"6b6163636f756e745f6b6579" + "6162" +
+		"6e7369676e696e675f7075626b6579" + "6173"
+	if got := hex.EncodeToString(data); got != want {
+		t.Fatalf("cbor = %s\nwant   %s", got, want)
+	}
+}
+
+func TestTestVectorCBORRejects(t *testing.T) {
+	vectors, err := GenerateTestVectors([]string{refMnemonic}, []int{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := vectors[0].MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	repeated := append([]byte{0xa6}, data[1:]...)
+	repeated = append(repeated, 0x65, 'i', 'n', 'd', 'e', 'x', 0x01)
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated", data[:len(data)-1]},
+		{"trailing bytes", append(append([]byte(nil), data...), 0x00)},
+		{"missing field", append([]byte{0xa4}, data[1+6+1:]...)},
+		{"repeated field", repeated},
+		{"not a map", []byte{0x80}},
+		{"indefinite map", []byte{0xbf, 0xff}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got TestVector
+			if err := got.UnmarshalCBOR(tt.data); !errors.Is(err, ErrEncoding) {
+				t.Fatalf("UnmarshalCBOR() = %v, want %v", err, ErrEncoding)
+			}
+		})
+	}
+}
+
+func TestKeyResultCBORRoundTrip(t *testing.T) {
+	want, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := want.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	again, err := want.MarshalCBOR()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, again) {
+		t.Fatal("two encodings of one result differ")
+	}
+	var got KeyResult
+	if err := got.UnmarshalCBOR(data); err != nil {
+		t.Fatal(err)
+	}
+	if got.AccountID != want.AccountID || got.Index != want.Index {
+		t.Fatalf("got index %d id %s, want %d %s", got.Index, got.AccountID, want.Index, want.AccountID)
+	}
+	if !got.Identity().Equals(want.Identity()) || !got.MasterKey().Equals(want.MasterKey()) || !got.OldAccountKey().Equals(want.OldAccountKey()) {
+		t.Fatal("rehydrated keys differ")
+	}
+
+	other, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 4)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := bytes.Replace(data, []byte(want.AccountID), []byte(other.AccountID), 1)
+	if err := got.UnmarshalCBOR(forged); !errors.Is(err, ErrAccountMismatch) {
+		t.Fatalf("UnmarshalCBOR(forged id) = %v, want %v", err, ErrAccountMismatch)
+	}
+}
@@ -0,0 +1,123 @@
+// anyr-keys derives an Anytype account key and account id from a BIP39 mnemonic.
+//
+// Usage:
+//   anyr-keys [--index N] [--format text|json] [--mnemonic-file PATH | --mnemonic PHRASE]
+//
+// With neither --mnemonic nor --mnemonic-file the phrase is read from stdin.
+// The mnemonic is never written to the output.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"runtime"
+
+	testvec "go-testvec"
+)
+
+// output is the --format json document.
+type output struct {
+	AccountID  string `json:"account_id"`
+	AccountKey string `json:"account_key"`
+	Index      uint   `json:"index"`
+}
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout, os.Stderr); err != nil {
+		if !errors.Is(err, flag.ErrHelp) {
+			fmt.Fprintln(os.Stderr, "anyr-keys:", err)
+		}
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	flags := flag.NewFlagSet("anyr-keys", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	phrase := flags.String("mnemonic", "", "recovery phrase (visible in the process list; prefer --mnemonic-file or stdin)")
+	file := flags.String("mnemonic-file", "", "read the recovery phrase from `path`, which must not be world-readable")
+	index := flags.Uint("index", 0, "account index")
+	format := flags.String("format", "text", "output format: text or json")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if flags.NArg() > 0 {
+		return fmt.Errorf("unexpected argument %q", flags.Arg(0))
+	}
+	if *format != "text" && *format != "json" {
+		return fmt.Errorf("unknown format %q", *format)
+	}
+	if *index > math.MaxUint32 {
+		return fmt.Errorf("index %d out of range", *index)
+	}
+	if *phrase != "" && *file != "" {
+		return errors.New("--mnemonic and --mnemonic-file are mutually exclusive")
+	}
+
+	raw := *phrase
+	switch {
+	case *file != "":
+		data, err := readMnemonicFile(*file)
+		if err != nil {
+			return err
+		}
+		raw = string(data)
+	case raw == "":
+		data, err := io.ReadAll(stdin)
+		if err != nil {
+			return fmt.Errorf("read stdin: %w", err)
+		}
+		raw = string(data)
+	}
+
+	mnemonic, err := testvec.NormalizeMnemonic(raw)
+	if err != nil {
+		return err
+	}
+	res, err := testvec.DeriveKeys(mnemonic, uint32(*index))
+	if err != nil {
+		return err
+	}
+	defer res.Wipe()
+	nodeBytes, err := res.MasterNode().MarshalBinary()
+	if err != nil {
+		return err
+	}
+	defer clear(nodeBytes)
+	out := output{
+		AccountID:  res.AccountID,
+		AccountKey: base64.StdEncoding.EncodeToString(nodeBytes),
+		Index:      *index,
+	}
+
+	if *format == "json" {
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return enc.Encode(out)
+	}
+	fmt.Fprintln(stdout, "account_key:", out.AccountKey)
+	fmt.Fprintln(stdout, "account_id: ", out.AccountID)
+	return nil
+}
+
+// readMnemonicFile reads path, refusing files that other users can read.
+func readMnemonicFile(path string) ([]byte, error) {
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+	if runtime.GOOS != "windows" && info.Mode().Perm()&0o004 != 0 {
+		return nil, fmt.Errorf("refusing to read %s: file is world-readable (mode %04o); run chmod 600 %s", path, info.Mode().Perm(), path)
+	}
+	return os.ReadFile(path)
+}
@@ -0,0 +1,110 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"testing"
+)
+
+const (
+	refMnemonic   = "tag volcano eight thank tide danger coast health above argue embrace heavy"
+	refAccountKey = "2x9TiDKFCAl79l5llFLvI4yU3P8KImRCm/STVr/iIU+leXyZof6C8KRr0666JX7wFvWprtOqnmK+W/1TTYWiTg=="
+	refAccountID  = "A9ZJ9CkjFnMLw8Lsgt8gnVTBqhrx1fRPbdCSucdpXxVi78WW"
+)
+
+func runCLI(t *testing.T, stdin string, args ...string) (string, error) {
+	t.Helper()
+	var stdout, stderr bytes.Buffer
+	err := run(args, strings.NewReader(stdin), &stdout, &stderr)
+	out := stdout.String() + stderr.String()
+	if err != nil {
+		// main prints the error, so it must not carry the phrase either.
+		out += err.Error()
+	}
+	for _, word := range strings.Fields(refMnemonic) {
+		if strings.Contains(out, word) {
+			t.Fatalf("output or error contains mnemonic word %q:\n%s", word, out)
+		}
+	}
+	return stdout.String(), err
+}
+
+func TestRunStdinText(t *testing.T) {
+	out, err := runCLI(t, refMnemonic+"\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "account_key: " + refAccountKey + "\naccount_id:  " + refAccountID + "\n"
+	if out != want {
+		t.Fatalf("output = %q, want %q", out, want)
+	}
+}
+
+func TestRunJSON(t *testing.T) {
+	out, err := runCLI(t, "", "--mnemonic", refMnemonic, "--format", "json")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got output
+	if err := json.Unmarshal([]byte(out), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got != (output{AccountID: refAccountID, AccountKey: refAccountKey}) {
+		t.Fatalf("unexpected output %+v", got)
+	}
+}
+
+func TestRunMnemonicFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phrase")
+	if err := os.WriteFile(path, []byte(refMnemonic), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCLI(t, "", "--mnemonic-file", path); err != nil {
+		t.Fatal(err)
+	}
+	if runtime.GOOS == "windows" {
+		return
+	}
+	if err := os.Chmod(path, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCLI(t, "", "--mnemonic-file", path); err == nil || !strings.Contains(err.Error(), "world-readable") {
+		t.Fatalf("expected world-readable refusal, got %v", err)
+	}
+}
+
+func TestRunBadMnemonicFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "phrase")
+	// A file holding a phrase with a bad checksum and surrounding text.
+	bad := "recovery: " + strings.Replace(refMnemonic, "heavy", "embrace", 1) + "\n"
+	if err := os.WriteFile(path, []byte(bad), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := runCLI(t, "", "--mnemonic-file", path); err == nil {
+		t.Fatal("expected an error for a malformed mnemonic file")
+	}
+	if _, err := runCLI(t, "", "--mnemonic-file", filepath.Join(t.TempDir(), "missing")); err == nil {
+		t.Fatal("expected an error for a missing mnemonic file")
+	}
+}
+
+func TestRunBadIndex(t *testing.T) {
+	for _, index := range []string{"2147483648", "4294967296", "-1"} {
+		if _, err := runCLI(t, refMnemonic, "--index", index); err == nil {
+			t.Fatalf("--index %s: expected an error", index)
+		}
+	}
+}
+
+func TestRunInvalidMnemonicNotEchoed(t *testing.T) {
+	if _, err := runCLI(t, "tag volcano eight thank tide danger coast health above argue embrace embrace"); err == nil {
+		t.Fatal("expected checksum error")
+	}
+}
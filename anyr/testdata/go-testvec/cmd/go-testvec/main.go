@@ -0,0 +1,71 @@
+// Standalone Go program to generate BIP39 → Anytype key derivation test vectors.
+// The vectors are derived by package testvec; its tests check every fixture
+// vector against the any-sync library's own derivation.
+//
+// Usage:
+//   cd anyr/testdata/go-testvec
+//   go run ./cmd/go-testvec           # print the reference vector
+//   go run ./cmd/go-testvec -write    # regenerate the JSON fixture
+//
+// After regenerating against a new any-sync, update testvec.AnySyncVersion.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package main
+
+import (
+	"encoding/base64"
+	"flag"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+
+	testvec "go-testvec"
+)
+
+// fixtureMnemonics are the phrases included in the JSON fixture.
+var fixtureMnemonics = []string{
+	"tag volcano eight thank tide danger coast health above argue embrace heavy",
+	"abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about",
+	"legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth useful legal winner thank year wave sausage worth title",
+}
+
+// fixtureIndices are the account indices derived for each fixture mnemonic.
+var fixtureIndices = []int{0, 1, 2}
+
+func main() {
+	write := flag.Bool("write", false, "write the JSON fixture to "+testvec.TestVectorsFile)
+	flag.Parse()
+
+	if *write {
+		vectors, err := testvec.GenerateTestVectors(fixtureMnemonics, fixtureIndices)
+		if err != nil {
+			panic(err)
+		}
+		if err := testvec.WriteTestVectors(testvec.TestVectorsFile, vectors); err != nil {
+			panic(err)
+		}
+		fmt.Printf("wrote %d vectors to %s\n", len(vectors), testvec.TestVectorsFile)
+		return
+	}
+
+	mnemonic := crypto.Mnemonic(fixtureMnemonics[0])
+
+	masterNode, err := testvec.DeriveMasterNode(mnemonic, 0)
+	if err != nil {
+		panic(err)
+	}
+	nodeBytes, err := masterNode.MarshalBinary()
+	if err != nil {
+		panic(err)
+	}
+
+	res, err := testvec.DeriveKeys(mnemonic, 0)
+	if err != nil {
+		panic(err)
+	}
+
+	fmt.Println("account_key:", base64.StdEncoding.EncodeToString(nodeBytes))
+	fmt.Println("account_id: ", res.Identity().GetPublic().Account())
+}
@@ -0,0 +1,290 @@
+// JSON and MessagePack encodings of KeyResult.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// keyResultFields are the encoded fields of a KeyResult, shared by the JSON
+// and MessagePack forms. The account key is the master node blob, from which
+// MasterKey and Identity are rederived on decode; the old-account key is on a
+// different path, so its 64-byte private key is stored. The mnemonic is never
+// part of the result and cannot be encoded.
+type keyResultFields struct {
+	AccountID     string `json:"account_id"`
+	AccountKey    string `json:"account_key"`
+	Index         uint32 `json:"index"`
+	OldAccountKey string `json:"old_account_key"`
+}
+
+// keyResultFieldCount is the number of fields in keyResultFields.
+const keyResultFieldCount = 4
+
+func (r *KeyResult) fields() (*keyResultFields, error) {
+	node, err := r.MasterNode().MarshalBinary()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(node)
+	old, err := r.OldAccountKey().Raw()
+	if err != nil {
+		return nil, err
+	}
+	defer clear(old)
+	return &keyResultFields{
+		AccountID:     r.AccountID,
+		AccountKey:    base64.StdEncoding.EncodeToString(node),
+		Index:         r.Index,
+		OldAccountKey: base64.StdEncoding.EncodeToString(old),
+	}, nil
+}
+
+// keyResult rebuilds a KeyResult, checking that the index is one DeriveKeys
+// accepts and that the account id matches the account key.
+func (f *keyResultFields) keyResult() (*KeyResult, error) {
+	if err := checkIndex(int(f.Index)); err != nil {
+		return nil, err
+	}
+	nodeBytes, err := base64.StdEncoding.DecodeString(f.AccountKey)
+	if err != nil {
+		return nil, fmt.Errorf("%w: account_key: %w", ErrEncoding, err)
+	}
+	defer clear(nodeBytes)
+	node, err := UnmarshalMasterNode(nodeBytes)
+	if err != nil {
+		return nil, err
+	}
+	old, err := base64.StdEncoding.DecodeString(f.OldAccountKey)
+	if err != nil {
+		node.Wipe()
+		return nil, fmt.Errorf("%w: old_account_key: %w", ErrEncoding, err)
+	}
+	defer clear(old)
+	if len(old) != ed25519.PrivateKeySize {
+		node.Wipe()
+		return nil, fmt.Errorf("%w: old_account_key is %d bytes, want %d", ErrEncoding, len(old), ed25519.PrivateKeySize)
+	}
+	_, oldPriv := (&extendedKey{key: old[:ed25519.SeedSize]}).privKey()
+	if !bytes.Equal(oldPriv[ed25519.SeedSize:], old[ed25519.SeedSize:]) {
+		node.Wipe()
+		clear(oldPriv)
+		return nil, fmt.Errorf("%w: old_account_key public half does not match its seed", ErrEncoding)
+	}
+	res := newKeyResult(f.Index, node.node, oldPriv)
+	if res.AccountID != f.AccountID {
+		res.Wipe()
+		return nil, ErrAccountMismatch
+	}
+	return res, nil
+}
+
+// MarshalJSON encodes the account id and index with both keys replaced by
+// "[REDACTED]", so a result that is logged or returned as JSON by accident
+// never carries key material. Use ExportJSON to serialize the keys.
+func (r *KeyResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(keyResultFields{
+		AccountID:     r.AccountID,
+		AccountKey:    redacted,
+		Index:         r.Index,
+		OldAccountKey: redacted,
+	})
+}
+
+// ExportJSON encodes the account id and index, and the account key and
+// old-account key as base64. It is the JSON counterpart of MarshalMsgpack,
+// for callers that deliberately persist the keys.
+func (r *KeyResult) ExportJSON() ([]byte, error) {
+	f, err := r.fields()
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(f)
+}
+
+// UnmarshalJSON restores a result written by ExportJSON. The keys are rebuilt
+// without PBKDF2, and the account id must match the account key. The redacted
+// output of MarshalJSON fails with ErrEncoding.
+func (r *KeyResult) UnmarshalJSON(data []byte) error {
+	var f keyResultFields
+	if err := json.Unmarshal(data, &f); err != nil {
+		return fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	res, err := f.keyResult()
+	if err != nil {
+		return err
+	}
+	*r = *res
+	return nil
+}
+
+// MarshalMsgpack encodes the same fields as ExportJSON as a MessagePack map.
+// The method set matches the Marshaler interface of common Go MessagePack
+// libraries, so a KeyResult can be passed to them directly.
+func (r *KeyResult) MarshalMsgpack() ([]byte, error) {
+	f, err := r.fields()
+	if err != nil {
+		return nil, err
+	}
+	b := []byte{0x80 | keyResultFieldCount}
+	b = msgpackString(msgpackString(b, "account_id"), f.AccountID)
+	b = msgpackString(msgpackString(b, "account_key"), f.AccountKey)
+	b = msgpackString(b, "index")
+	b = binary.BigEndian.AppendUint32(append(b, 0xce), f.Index)
+	b = msgpackString(msgpackString(b, "old_account_key"), f.OldAccountKey)
+	return b, nil
+}
+
+// UnmarshalMsgpack restores a result written by MarshalMsgpack. Unknown keys
+// are ignored; every field of MarshalMsgpack is required.
+func (r *KeyResult) UnmarshalMsgpack(data []byte) error {
+	d := msgpackDecoder{data: data}
+	n := d.mapLen()
+	var f keyResultFields
+	var seen uint
+	for range n {
+		switch d.string() {
+		case "account_id":
+			d.mark(&seen, 0)
+			f.AccountID = d.string()
+		case "account_key":
+			d.mark(&seen, 1)
+			f.AccountKey = d.string()
+		case "index":
+			d.mark(&seen, 2)
+			f.Index = d.uint32()
+		case "old_account_key":
+			d.mark(&seen, 3)
+			f.OldAccountKey = d.string()
+		default:
+			d.skip()
+		}
+	}
+	if err := d.finish(seen, keyResultFieldCount); err != nil {
+		return err
+	}
+	res, err := f.keyResult()
+	if err != nil {
+		return err
+	}
+	*r = *res
+	return nil
+}
+
+// msgpackString appends s as a MessagePack str.
+func msgpackString(b []byte, s string) []byte {
+	switch n := len(s); {
+	case n < 32:
+		b = append(b, 0xa0|byte(n))
+	case n <= math.MaxUint8:
+		b = append(b, 0xd9, byte(n))
+	default:
+		b = binary.BigEndian.AppendUint16(append(b, 0xda), uint16(n))
+	}
+	return append(b, s...)
+}
+
+// msgpackDecoder reads the subset of MessagePack that MarshalMsgpack writes:
+// maps, strings and unsigned integers. The first error sticks.
+type msgpackDecoder struct {
+	data []byte
+	err  error
+}
+
+func (d *msgpackDecoder) fail(msg string) {
+	if d.err == nil {
+		d.err = fmt.Errorf("%w: msgpack: %s", ErrEncoding, msg)
+	}
+	d.data = nil
+}
+
+// mark records that field bit was read, failing if it was read before.
+func (d *msgpackDecoder) mark(seen *uint, bit uint) {
+	if *seen&(1<<bit) != 0 {
+		d.fail("repeated field")
+	}
+	*seen |= 1 << bit
+}
+
+// finish checks for trailing bytes and that fields 0..want-1 were all read.
+func (d *msgpackDecoder) finish(seen uint, want int) error {
+	if d.err == nil && len(d.data) != 0 {
+		d.fail("trailing bytes")
+	}
+	if d.err == nil && seen != 1<<want-1 {
+		d.fail("missing fields")
+	}
+	return d.err
+}
+
+func (d *msgpackDecoder) next(n int) []byte {
+	if len(d.data) < n {
+		d.fail("unexpected end of data")
+		return make([]byte, n)
+	}
+	b := d.data[:n]
+	d.data = d.data[n:]
+	return b
+}
+
+func (d *msgpackDecoder) mapLen() int {
+	switch c := d.next(1)[0]; {
+	case c&0xf0 == 0x80:
+		return int(c & 0x0f)
+	case c == 0xde:
+		return int(binary.BigEndian.Uint16(d.next(2)))
+	default:
+		d.fail("expected map")
+		return 0
+	}
+}
+
+func (d *msgpackDecoder) string() string {
+	var n int
+	switch c := d.next(1)[0]; {
+	case c&0xe0 == 0xa0:
+		n = int(c & 0x1f)
+	case c == 0xd9:
+		n = int(d.next(1)[0])
+	case c == 0xda:
+		n = int(binary.BigEndian.Uint16(d.next(2)))
+	default:
+		d.fail("expected string")
+		return ""
+	}
+	return string(d.next(n))
+}
+
+func (d *msgpackDecoder) uint32() uint32 {
+	switch c := d.next(1)[0]; {
+	case c < 0x80:
+		return uint32(c)
+	case c == 0xcc:
+		return uint32(d.next(1)[0])
+	case c == 0xcd:
+		return uint32(binary.BigEndian.Uint16(d.next(2)))
+	case c == 0xce:
+		return binary.BigEndian.Uint32(d.next(4))
+	default:
+		d.fail("expected uint32")
+		return 0
+	}
+}
+
+// skip discards a string or unsigned integer value.
+func (d *msgpackDecoder) skip() {
+	if len(d.data) > 0 && (d.data[0]&0xe0 == 0xa0 || d.data[0] == 0xd9 || d.data[0] == 0xda) {
+		d.string()
+		return
+	}
+	d.uint32()
+}
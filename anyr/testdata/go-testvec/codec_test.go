@@ -0,0 +1,177 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestKeyResultCodecRoundTrip(t *testing.T) {
+	want, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	codecs := []struct {
+		name      string
+		marshal   func(*KeyResult) ([]byte, error)
+		unmarshal func(*KeyResult, []byte) error
+	}{
+		{"json", (*KeyResult).ExportJSON, func(r *KeyResult, b []byte) error { return json.Unmarshal(b, r) }},
+		{"msgpack", (*KeyResult).MarshalMsgpack, (*KeyResult).UnmarshalMsgpack},
+	}
+	for _, c := range codecs {
+		t.Run(c.name, func(t *testing.T) {
+			data, err := c.marshal(want)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, w := range strings.Fields(refMnemonic) {
+				if strings.Contains(string(data), w) {
+					t.Fatalf("encoding contains mnemonic word %q", w)
+				}
+			}
+			var got KeyResult
+			if err := c.unmarshal(&got, data); err != nil {
+				t.Fatal(err)
+			}
+			if got.Index != want.Index || got.AccountID != want.AccountID {
+				t.Fatalf("got index %d id %s, want %d %s", got.Index, got.AccountID, want.Index, want.AccountID)
+			}
+			for _, k := range []struct {
+				name      string
+				got, want crypto.PrivKey
+			}{
+				{"MasterKey", got.MasterKey(), want.MasterKey()},
+				{"OldAccountKey", got.OldAccountKey(), want.OldAccountKey()},
+				{"Identity", got.Identity(), want.Identity()},
+			} {
+				if !k.got.GetPublic().Equals(k.want.GetPublic()) {
+					t.Fatalf("%s public key differs", k.name)
+				}
+				sig, err := k.got.Sign([]byte("payload"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				if ok, err := k.want.GetPublic().Verify([]byte("payload"), sig); err != nil || !ok {
+					t.Fatalf("%s: rehydrated key does not sign for the original public key", k.name)
+				}
+			}
+		})
+	}
+}
+
+func TestKeyResultUnmarshalRejects(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := res.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		t.Fatal(err)
+	}
+	other, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherData, err := other.ExportJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var otherFields map[string]any
+	if err := json.Unmarshal(otherData, &otherFields); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name  string
+		key   string
+		value any
+		want  error
+	}{
+		{"wrong account id", "account_id", other.AccountID, ErrAccountMismatch},
+		{"bad account key", "account_key", "not base64!", ErrEncoding},
+		{"short old key", "old_account_key", "AAAA", ErrEncoding},
+		{"mismatched old key halves", "old_account_key", otherFields["account_key"], ErrEncoding},
+		{"redacted account key", "account_key", redacted, ErrEncoding},
+		{"hardened index", "index", uint32(firstHardenedIndex), ErrInvalidIndex},
+		{"max index", "index", uint32(math.MaxUint32), ErrInvalidIndex},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := map[string]any{}
+			for k, v := range fields {
+				f[k] = v
+			}
+			f[tt.key] = tt.value
+			b, err := json.Marshal(f)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got KeyResult
+			if err := json.Unmarshal(b, &got); !errors.Is(err, tt.want) {
+				t.Fatalf("UnmarshalJSON() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+
+	packed, err := res.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, bad := range [][]byte{nil, packed[:len(packed)-1], append(append([]byte(nil), packed...), 0x00), {0x90}} {
+		var got KeyResult
+		if err := got.UnmarshalMsgpack(bad); !errors.Is(err, ErrEncoding) {
+			t.Fatalf("UnmarshalMsgpack(%d bytes) = %v, want %v", len(bad), err, ErrEncoding)
+		}
+	}
+
+	f, err := res.fields()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Four known keys, with account_id repeated in place of index.
+	dup := []byte{0x80 | keyResultFieldCount}
+	dup = msgpackString(msgpackString(dup, "account_id"), f.AccountID)
+	dup = msgpackString(msgpackString(dup, "account_key"), f.AccountKey)
+	dup = msgpackString(msgpackString(dup, "account_id"), f.AccountID)
+	dup = msgpackString(msgpackString(dup, "old_account_key"), f.OldAccountKey)
+	var got KeyResult
+	if err := got.UnmarshalMsgpack(dup); !errors.Is(err, ErrEncoding) {
+		t.Fatalf("UnmarshalMsgpack(repeated account_id, no index) = %v, want %v", err, ErrEncoding)
+	}
+
+	hardened := bytes.Replace(packed, binary.BigEndian.AppendUint32([]byte{0xce}, 0), binary.BigEndian.AppendUint32([]byte{0xce}, math.MaxUint32), 1)
+	if err := got.UnmarshalMsgpack(hardened); !errors.Is(err, ErrInvalidIndex) {
+		t.Fatalf("UnmarshalMsgpack(index %d) = %v, want %v", uint32(math.MaxUint32), err, ErrInvalidIndex)
+	}
+}
+
+func TestKeyResultMsgpackLayout(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	packed, err := res.MarshalMsgpack()
+	if err != nil {
+		t.Fatal(err)
+	}
+	// fixmap of 4, fixstr "account_id", str8 of the 48-character id.
+	want := append([]byte{0x84, 0xaa}, "account_id"...)
+	want = append(append(want, 0xd9, 48), refAccountID...)
+	if !strings.HasPrefix(string(packed), string(want)) {
+		t.Fatalf("msgpack prefix = %x, want %x", packed[:len(want)], want)
+	}
+}
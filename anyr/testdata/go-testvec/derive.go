@@ -0,0 +1,242 @@
+// Package testvec wraps the any-sync key derivation used by Anytype, and is the
+// Go reference implementation that the Rust derivation in anyr is checked against.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"crypto/ed25519"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	// anytypeAccountPath is m/44'/2046', the parent of every account node.
+	anytypeAccountPath = []uint32{44, 2046}
+	// anytypeAccountOldPath is m/44'/607', used for account keys before the
+	// move to the registered SLIP-44 coin type 2046.
+	anytypeAccountOldPath = []uint32{44, 607}
+)
+
+// KeyResult holds the keys derived for one account index.
+type KeyResult struct {
+	// Index is the plain account number in 0..2^31-1, without the hardened bit.
+	Index uint32
+	// AccountID is the Anytype account id of the identity key.
+	AccountID string
+
+	// The master node and private keys are only reachable through the
+	// accessor methods; the keys are held as their 64-byte Ed25519 form.
+	masterNode    *MasterNode
+	masterKey     Secret
+	oldAccountKey Secret
+	identity      Secret
+}
+
+// MasterNode returns the node at m/44'/2046'/index'. The node shares its
+// bytes with the result and is zeroed by Wipe.
+func (r *KeyResult) MasterNode() *MasterNode { return r.masterNode }
+
+// MasterKey returns the current signing key, the key of m/44'/2046'/index'.
+// The key shares its bytes with the result and is zeroed by Wipe.
+func (r *KeyResult) MasterKey() crypto.PrivKey { return secretPrivKey(r.masterKey) }
+
+// OldAccountKey returns the old-account signing key, the key of
+// m/44'/607'/index'. Older any-sync releases returned it alongside MasterKey;
+// spaces created by those accounts are signed with it. The key shares its
+// bytes with the result and is zeroed by Wipe.
+func (r *KeyResult) OldAccountKey() crypto.PrivKey { return secretPrivKey(r.oldAccountKey) }
+
+// Identity returns the identity key at m/44'/2046'/index'/0', the key the
+// account id encodes. The key shares its bytes with the result and is zeroed
+// by Wipe.
+func (r *KeyResult) Identity() crypto.PrivKey { return secretPrivKey(r.identity) }
+
+// secretPrivKey wraps the Ed25519 private key bytes held by s without copying.
+func secretPrivKey(s Secret) crypto.PrivKey {
+	return crypto.NewEd25519PrivKey(ed25519.PrivateKey(s.Reveal()))
+}
+
+// Wipe overwrites the private keys and master node with zeros. The keys must
+// not be used afterwards; AccountID and Index are left intact.
+func (r *KeyResult) Wipe() {
+	r.masterKey.Wipe()
+	r.oldAccountKey.Wipe()
+	r.identity.Wipe()
+	r.masterNode.Wipe()
+}
+
+// Format implements fmt.Formatter. Every verb prints the index and account id
+// with the keys redacted, so a KeyResult or *KeyResult is safe to log.
+func (r KeyResult) Format(f fmt.State, _ rune) {
+	fmt.Fprintf(f, "KeyResult{Index: %d, AccountID: %s, keys: %s}", r.Index, r.AccountID, redacted)
+}
+
+// DeriveMasterNode validates the mnemonic and derives the account master node
+// at path m/44'/2046'/index'. index is the plain account number; see DeriveKeys.
+func DeriveMasterNode(m crypto.Mnemonic, index uint32) (*MasterNode, error) {
+	start := time.Now()
+	node, err := deriveMasterNode(m, index)
+	logDerivation("DeriveMasterNode", start, err, "index", index)
+	return node, err
+}
+
+func deriveMasterNode(m crypto.Mnemonic, index uint32) (*MasterNode, error) {
+	if err := checkIndex(int(index)); err != nil {
+		return nil, err
+	}
+	seed, err := validatedSeed(context.Background(), m)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	path := append(append([]uint32(nil), anytypeAccountPath...), index)
+	return &MasterNode{node: slip10Derive(seed, path)}, nil
+}
+
+// DeriveKeys validates the mnemonic and derives the account keys for index.
+// index is the plain account number 0..2^31-1, which is hardened internally;
+// an index that already has the hardened bit set (see IsHardenedIndex) fails
+// with ErrInvalidIndex rather than being silently masked.
+// Invalid phrases return ErrMnemonicLength, ErrMnemonicWord or ErrMnemonicChecksum,
+// all of which match ErrInvalidMnemonic.
+func DeriveKeys(m crypto.Mnemonic, index uint32) (*KeyResult, error) {
+	return DeriveKeysContext(context.Background(), m, int(index))
+}
+
+// DeriveKeysContext is like DeriveKeys but stops with ErrDerivation wrapping
+// ctx.Err() if ctx is cancelled. The PBKDF2 seed expansion checks ctx between chunks of rounds,
+// so a cancelled request releases its goroutine promptly.
+func DeriveKeysContext(ctx context.Context, m crypto.Mnemonic, index int) (*KeyResult, error) {
+	start := time.Now()
+	if err := checkIndex(index); err != nil {
+		logDerivation("DeriveKeys", start, err, "index", index)
+		return nil, err
+	}
+	prefixes, err := deriveAccountPrefixes(ctx, m)
+	if err != nil {
+		logDerivation("DeriveKeys", start, err, "index", index)
+		return nil, err
+	}
+	defer prefixes.wipe()
+	res := prefixes.keys(uint32(index))
+	logDerivation("DeriveKeys", start, nil, "index", index, "account_id", res.AccountID)
+	return res, nil
+}
+
+// DeriveAccountRange derives the keys for account indices start..start+count-1.
+// The seed and the m/44'/2046' prefix node are computed once and shared by
+// every index, so the cost of a range is close to the cost of a single account.
+func DeriveAccountRange(m crypto.Mnemonic, start, count int) ([]*KeyResult, error) {
+	began := time.Now()
+	if start < 0 || count < 0 || uint64(start)+uint64(count) > uint64(firstHardenedIndex) {
+		err := fmt.Errorf("%w: range start %d count %d exceeds 0..2^31-1", ErrInvalidIndex, start, count)
+		logDerivation("DeriveAccountRange", began, err, "start", start, "count", count)
+		return nil, err
+	}
+	prefixes, err := deriveAccountPrefixes(context.Background(), m)
+	if err != nil {
+		logDerivation("DeriveAccountRange", began, err, "start", start, "count", count)
+		return nil, err
+	}
+	defer prefixes.wipe()
+
+	results := make([]*KeyResult, 0, count)
+	for i := range count {
+		results = append(results, prefixes.keys(uint32(start+i)))
+	}
+	logDerivation("DeriveAccountRange", began, nil, "start", start, "count", count)
+	return results, nil
+}
+
+// IsHardenedIndex reports whether n has the SLIP-10 hardened bit (2^31) set.
+// Account indices passed to this package are plain account numbers in
+// 0..2^31-1, for which IsHardenedIndex is false; the package sets the hardened
+// bit itself when it derives m/44'/2046'/index'.
+func IsHardenedIndex(n uint32) bool {
+	return n >= firstHardenedIndex
+}
+
+// checkIndex rejects account indices outside 0..2^31-1. An index with the
+// hardened bit set is reported as such, since the caller most likely hardened
+// it already.
+func checkIndex(index int) error {
+	switch {
+	case index >= 0 && uint64(index) <= math.MaxUint32 && IsHardenedIndex(uint32(index)):
+		return fmt.Errorf("%w: %#x has the hardened bit set; pass the plain account number %d", ErrInvalidIndex, index, uint32(index)&^firstHardenedIndex)
+	case index < 0 || uint64(index) >= uint64(firstHardenedIndex):
+		return fmt.Errorf("%w: %d", ErrInvalidIndex, index)
+	}
+	return nil
+}
+
+// accountPrefixes holds the SLIP-10 nodes that account nodes are children of,
+// so several indices can be derived from one seed expansion.
+type accountPrefixes struct {
+	// current is m/44'/2046'.
+	current *extendedKey
+	// old is m/44'/607'.
+	old *extendedKey
+}
+
+// deriveAccountPrefixes validates the mnemonic and derives both account prefixes.
+func deriveAccountPrefixes(ctx context.Context, m crypto.Mnemonic) (*accountPrefixes, error) {
+	seed, err := validatedSeed(ctx, m)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	return seedAccountPrefixes(seed), nil
+}
+
+// seedAccountPrefixes derives both account prefixes from a BIP39 seed.
+func seedAccountPrefixes(seed []byte) *accountPrefixes {
+	return &accountPrefixes{
+		current: slip10Derive(seed, anytypeAccountPath),
+		old:     slip10Derive(seed, anytypeAccountOldPath),
+	}
+}
+
+// keys derives the account keys for index below the prefixes. The result does
+// not share any buffers with the prefixes.
+func (p *accountPrefixes) keys(index uint32) *KeyResult {
+	oldNode := p.old.child(index)
+	_, oldPriv := oldNode.privKey()
+	oldNode.wipe()
+	return newKeyResult(index, p.current.child(index), oldPriv)
+}
+
+// newKeyResult derives the current signing and identity keys from the account
+// node and takes ownership of node and the old-account key bytes.
+func newKeyResult(index uint32, node *extendedKey, oldPriv []byte) *KeyResult {
+	_, masterPriv := node.privKey()
+	mn := &MasterNode{node: node}
+	identity, identityPriv := mn.identity()
+	return &KeyResult{
+		Index:         index,
+		AccountID:     identity.GetPublic().Account(),
+		masterNode:    mn,
+		masterKey:     NewSecret(masterPriv),
+		oldAccountKey: NewSecret(oldPriv),
+		identity:      NewSecret(identityPriv),
+	}
+}
+
+func (p *accountPrefixes) wipe() {
+	p.current.wipe()
+	p.old.wipe()
+}
+
+// validatedSeed validates the mnemonic and computes its BIP39 seed.
+func validatedSeed(ctx context.Context, m crypto.Mnemonic) ([]byte, error) {
+	if err := ValidateMnemonic(string(m)); err != nil {
+		return nil, err
+	}
+	return mnemonicSeed(ctx, string(m), "")
+}
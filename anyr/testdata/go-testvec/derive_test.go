@@ -0,0 +1,186 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/go-slip10"
+)
+
+func TestDeriveAccountRange(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	results, err := DeriveAccountRange(m, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 3 {
+		t.Fatalf("got %d results, want 3", len(results))
+	}
+	for i, res := range results {
+		index := uint32(2 + i)
+		if res.Index != index {
+			t.Fatalf("results[%d].Index = %d, want %d", i, res.Index, index)
+		}
+		want, err := DeriveKeys(m, index)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !res.Identity().Equals(want.Identity()) || !res.MasterKey().Equals(want.MasterKey()) {
+			t.Fatalf("index %d: keys differ from DeriveKeys", index)
+		}
+		if res.AccountID != want.Identity().GetPublic().Account() {
+			t.Fatalf("index %d: account id %s, want %s", index, res.AccountID, want.Identity().GetPublic().Account())
+		}
+	}
+}
+
+func TestDeriveAccountRangeBounds(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	tests := []struct {
+		name         string
+		start, count int
+	}{
+		{"negative start", -1, 1},
+		{"negative count", 0, -1},
+		{"past ceiling", 1<<31 - 1, 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := DeriveAccountRange(m, tt.start, tt.count); !errors.Is(err, ErrInvalidIndex) {
+				t.Fatalf("DeriveAccountRange() = %v, want %v", err, ErrInvalidIndex)
+			}
+		})
+	}
+
+	results, err := DeriveAccountRange(m, 1<<31-1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(results) != 1 || results[0].Index != 1<<31-1 {
+		t.Fatalf("unexpected result for last index: %+v", results)
+	}
+}
+
+func TestDeriveKeysSigningKeys(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	res, err := DeriveKeys(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.MasterKey().Equals(res.OldAccountKey()) {
+		t.Fatal("MasterKey and OldAccountKey are the same key")
+	}
+
+	// Current signing key must match any-sync.
+	ref, err := m.DeriveKeys(0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.MasterKey().GetPublic().Equals(ref.MasterKey.GetPublic()) {
+		t.Fatal("MasterKey differs from any-sync")
+	}
+
+	// Old-account key is the m/44'/607'/0' node key, as in any-sync v0.4.
+	seed, err := m.Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldNode, err := slip10.DeriveForPath("m/44'/607'/0'", seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oldKey, _, err := crypto.GenerateEd25519Key(bytes.NewReader(oldNode.RawSeed()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !res.OldAccountKey().Equals(oldKey) {
+		t.Fatal("OldAccountKey differs from m/44'/607'/0'")
+	}
+
+	for _, tt := range []struct {
+		name string
+		key  crypto.PrivKey
+		want string
+	}{
+		{"MasterKey", res.MasterKey(), "MpqmS05MJZPMPYMYmw0sX1oudSK/A6zncoa/rixLqDc="},
+		{"OldAccountKey", res.OldAccountKey(), "reQ/cLZwz+xDnhLPn51m4KHjlyXIZpYbuc84XpCHc3k="},
+	} {
+		raw, err := tt.key.GetPublic().Raw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := base64.StdEncoding.EncodeToString(raw); got != tt.want {
+			t.Fatalf("%s public key = %s, want %s", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestKeyResultWipe(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	res.Wipe()
+	for _, tt := range []struct {
+		name string
+		key  crypto.PrivKey
+	}{
+		{"MasterKey", res.MasterKey()},
+		{"OldAccountKey", res.OldAccountKey()},
+		{"Identity", res.Identity()},
+	} {
+		raw, err := tt.key.Raw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(raw, make([]byte, len(raw))) {
+			t.Fatalf("%s not wiped", tt.name)
+		}
+	}
+	if got, _ := res.MasterNode().MarshalBinary(); !bytes.Equal(got, make([]byte, masterNodeLen)) {
+		t.Fatal("MasterNode not wiped")
+	}
+	if res.AccountID != refAccountID {
+		t.Fatalf("AccountID = %s after Wipe, want %s", res.AccountID, refAccountID)
+	}
+}
+
+func TestHardenedIndexBoundary(t *testing.T) {
+	for _, tt := range []struct {
+		n        uint32
+		hardened bool
+	}{
+		{0, false},
+		{1<<31 - 1, false},
+		{1 << 31, true},
+		{1<<31 | 5, true},
+		{1<<32 - 1, true},
+	} {
+		if got := IsHardenedIndex(tt.n); got != tt.hardened {
+			t.Fatalf("IsHardenedIndex(%#x) = %v, want %v", tt.n, got, tt.hardened)
+		}
+	}
+
+	m := crypto.Mnemonic(refMnemonic)
+	last, err := DeriveKeys(m, 1<<31-1)
+	if err != nil {
+		t.Fatalf("DeriveKeys(2^31-1) = %v", err)
+	}
+	if last.Index != 1<<31-1 {
+		t.Fatalf("Index = %d, want 2^31-1", last.Index)
+	}
+	for _, index := range []uint32{1 << 31, 1<<31 | 5, 1<<32 - 1} {
+		if _, err := DeriveKeys(m, index); !errors.Is(err, ErrInvalidIndex) {
+			t.Fatalf("DeriveKeys(%#x) = %v, want %v", index, err, ErrInvalidIndex)
+		}
+		if _, err := DeriveMasterNode(m, index); !errors.Is(err, ErrInvalidIndex) {
+			t.Fatalf("DeriveMasterNode(%#x) = %v, want %v", index, err, ErrInvalidIndex)
+		}
+	}
+}
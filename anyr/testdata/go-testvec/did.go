@@ -0,0 +1,68 @@
+// did:key identifiers for account keys.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	// didKeyPrefix is the did:key method prefix followed by the base58btc
+	// multibase code.
+	didKeyPrefix = "did:key:z"
+	// ed25519PubKeyLen is the size of a raw Ed25519 public key.
+	ed25519PubKeyLen = 32
+)
+
+// ed25519Multicodec is the varint-encoded multicodec code 0xed (ed25519-pub).
+var ed25519Multicodec = []byte{0xed, 0x01}
+
+// DIDKey returns the did:key identifier (did:key:z6Mk...) of the node's identity
+// key, the same key the account id encodes.
+func (n *MasterNode) DIDKey() (string, error) {
+	identity, priv := n.identity()
+	defer clear(priv)
+	return PubKeyToDIDKey(identity.GetPublic())
+}
+
+// PubKeyToDIDKey returns the did:key identifier of an Ed25519 public key.
+// Other key types fail with ErrUnsupportedKeyType.
+func PubKeyToDIDKey(pk crypto.PubKey) (string, error) {
+	if err := checkEd25519PubKey("did:key", pk); err != nil {
+		return "", err
+	}
+	raw, err := pk.Raw()
+	if err != nil {
+		return "", err
+	}
+	return didKeyPrefix + base58.Encode(append(append([]byte(nil), ed25519Multicodec...), raw...)), nil
+}
+
+// ParseDIDKey parses an Ed25519 did:key identifier. Other key types and
+// multibase encodings fail with ErrEncoding.
+func ParseDIDKey(did string) (crypto.PubKey, error) {
+	encoded, ok := strings.CutPrefix(did, didKeyPrefix)
+	if !ok {
+		return nil, fmt.Errorf("%w: did:key must start with %q", ErrEncoding, didKeyPrefix)
+	}
+	raw, err := base58.Decode(encoded)
+	if err != nil {
+		return nil, fmt.Errorf("%w: did:key is not valid base58: %w", ErrEncoding, err)
+	}
+	key, ok := bytes.CutPrefix(raw, ed25519Multicodec)
+	if !ok {
+		return nil, fmt.Errorf("%w: did:key is not an ed25519 key", ErrEncoding)
+	}
+	if len(key) != ed25519PubKeyLen {
+		return nil, fmt.Errorf("%w: did:key holds %d key bytes, want %d", ErrEncoding, len(key), ed25519PubKeyLen)
+	}
+	return crypto.UnmarshalEd25519PublicKey(key)
+}
@@ -0,0 +1,65 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+const refDIDKey = "did:key:z6Mkr7t1ECKEwiyDCWVFHQTaTgy1pe2EhEbunEi34MepUjr7"
+
+func TestDIDKeyReference(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	did, err := node.DIDKey()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if did != refDIDKey {
+		t.Fatalf("DIDKey() = %s, want %s", did, refDIDKey)
+	}
+
+	pk, err := ParseDIDKey(did)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, err := AccountToPubKey(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !pk.Equals(want) {
+		t.Fatal("did:key public key differs from the account id's")
+	}
+	if again, _ := PubKeyToDIDKey(pk); again != did {
+		t.Fatalf("round trip = %s, want %s", again, did)
+	}
+}
+
+func TestParseDIDKeyErrors(t *testing.T) {
+	tests := []struct {
+		name string
+		did  string
+	}{
+		{"empty", ""},
+		{"other method", "did:web:example.com"},
+		{"base64 multibase", "did:key:m7QE"},
+		{"bad base58", "did:key:z0OIl"},
+		{"secp256k1", "did:key:zQ3shokFTS3brHcDQrn82RUDfCZESWL1ZdCEJwekUDPQiYBme"},
+		{"truncated", refDIDKey[:len(refDIDKey)-2]},
+		{"trailing bytes", didKeyPrefix + base58.Encode(append(append([]byte{0xed, 0x01}, make([]byte, 32)...), 0x00))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := ParseDIDKey(tt.did); !errors.Is(err, ErrEncoding) {
+				t.Fatalf("ParseDIDKey(%q) = %v, want %v", tt.did, err, ErrEncoding)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+// Alternative renderings of account ids.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"encoding/base32"
+	"fmt"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+// Encoding selects how EncodeAccountID renders an account id.
+//
+// Every encoding carries the same 35-byte payload as the default form,
+// 0x5b || pubkey[32] || crc16, so the checksum survives in each of them. The
+// multibase encodings prefix the text with their multibase code, which lets
+// DecodeAccountID tell them apart from the default form (always 'A').
+type Encoding int
+
+const (
+	// EncodingDefault is the strkey form returned by Account(), e.g. "A9ZJ...".
+	EncodingDefault Encoding = iota
+	// EncodingBase58 is multibase base58btc: 'z' followed by the default form.
+	EncodingBase58
+	// EncodingBase32 is multibase base32: 'b' followed by lowercase RFC 4648
+	// base32 without padding.
+	EncodingBase32
+)
+
+const (
+	multibaseBase58 = 'z'
+	multibaseBase32 = 'b'
+)
+
+// base32NoPad is the lowercase RFC 4648 alphabet used by multibase 'b'.
+var base32NoPad = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// EncodeAccountID renders the account id of pk in enc. pk must be an Ed25519
+// key; other key types fail with ErrUnsupportedKeyType.
+func EncodeAccountID(pk crypto.PubKey, enc Encoding) (string, error) {
+	id, err := PubKeyToAccount(pk)
+	if err != nil {
+		return "", err
+	}
+	switch enc {
+	case EncodingDefault:
+		return id, nil
+	case EncodingBase58:
+		return string(multibaseBase58) + id, nil
+	case EncodingBase32:
+		raw, err := base58.Decode(id)
+		if err != nil {
+			return "", fmt.Errorf("%w: %w", ErrEncoding, err)
+		}
+		return string(multibaseBase32) + base32NoPad.EncodeToString(raw), nil
+	default:
+		return "", fmt.Errorf("%w: unknown account id encoding %d", ErrEncoding, enc)
+	}
+}
+
+// DecodeAccountID parses an account id in any Encoding, detected from its
+// first byte, and returns its public key. The version byte and checksum are
+// verified as in AccountToPubKey.
+func DecodeAccountID(s string) (crypto.PubKey, error) {
+	if s == "" {
+		return nil, fmt.Errorf("%w: empty account id", ErrEncoding)
+	}
+	switch s[0] {
+	case multibaseBase58:
+		return AccountToPubKey(s[1:])
+	case multibaseBase32:
+		raw, err := base32NoPad.DecodeString(strings.ToLower(s[1:]))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrBadAccountEncoding, err)
+		}
+		return accountPayloadToPubKey(raw)
+	default:
+		return AccountToPubKey(s)
+	}
+}
@@ -0,0 +1,69 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestAccountIDEncodingsRoundTrip(t *testing.T) {
+	pk, err := AccountToPubKey(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		enc    Encoding
+		prefix string
+	}{
+		{EncodingDefault, "A"},
+		{EncodingBase58, "zA"},
+		{EncodingBase32, "bl"},
+	}
+	for _, tt := range tests {
+		id, err := EncodeAccountID(pk, tt.enc)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if id[:len(tt.prefix)] != tt.prefix {
+			t.Fatalf("encoding %d: %s does not start with %q", tt.enc, id, tt.prefix)
+		}
+		got, err := DecodeAccountID(id)
+		if err != nil {
+			t.Fatalf("encoding %d: %v", tt.enc, err)
+		}
+		if !got.Equals(pk) {
+			t.Fatalf("encoding %d: decoded public key differs", tt.enc)
+		}
+	}
+	if id, _ := EncodeAccountID(pk, EncodingDefault); id != refAccountID {
+		t.Fatalf("default encoding = %s, want %s", id, refAccountID)
+	}
+}
+
+func TestDecodeAccountIDErrors(t *testing.T) {
+	pk, err := AccountToPubKey(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b32, err := EncodeAccountID(pk, EncodingBase32)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := EncodeAccountID(pk, Encoding(99)); !errors.Is(err, ErrEncoding) {
+		t.Fatalf("EncodeAccountID(99) = %v, want %v", err, ErrEncoding)
+	}
+	for _, id := range []string{
+		"",
+		"z",
+		"b1",
+		b32[:len(b32)-2],
+		b32[:len(b32)-1] + "a",
+		"z" + refAccountID[:len(refAccountID)-1] + "X",
+	} {
+		if _, err := DecodeAccountID(id); !errors.Is(err, ErrEncoding) {
+			t.Fatalf("DecodeAccountID(%q) = %v, want %v", id, err, ErrEncoding)
+		}
+	}
+}
@@ -0,0 +1,54 @@
+// Mnemonics from the environment, redacted when formatted.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// redacted replaces secrets wherever they would be printed or serialized.
+const redacted = "[REDACTED]"
+
+// RedactedMnemonic holds a mnemonic that prints, logs and marshals as
+// "[REDACTED]" with every fmt verb, encoding/json, encoding.TextMarshaler and
+// log/slog. Reveal returns the phrase.
+type RedactedMnemonic struct {
+	m crypto.Mnemonic
+}
+
+// Reveal returns the phrase. Pass its result straight to derivation rather
+// than storing it.
+func (r RedactedMnemonic) Reveal() crypto.Mnemonic { return r.m }
+
+func (r RedactedMnemonic) String() string   { return redacted }
+func (r RedactedMnemonic) GoString() string { return redacted }
+
+// Format implements fmt.Formatter so that no verb, including %x and %q,
+// formats the phrase.
+func (r RedactedMnemonic) Format(f fmt.State, _ rune) { io.WriteString(f, redacted) }
+
+func (r RedactedMnemonic) MarshalText() ([]byte, error) { return []byte(redacted), nil }
+func (r RedactedMnemonic) LogValue() slog.Value         { return slog.StringValue(redacted) }
+
+// MnemonicFromEnv reads a phrase from the environment variable varName,
+// cleans it up with NormalizeMnemonic and validates it. Errors name the
+// variable but never include its value.
+func MnemonicFromEnv(varName string) (RedactedMnemonic, error) {
+	raw, ok := os.LookupEnv(varName)
+	if !ok {
+		return RedactedMnemonic{}, fmt.Errorf("%w: environment variable %s is not set", ErrInvalidMnemonic, varName)
+	}
+	m, err := NormalizeMnemonic(raw)
+	if err != nil {
+		return RedactedMnemonic{}, fmt.Errorf("environment variable %s: %w", varName, err)
+	}
+	return RedactedMnemonic{m: m}, nil
+}
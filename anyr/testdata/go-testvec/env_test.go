@@ -0,0 +1,62 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+const testMnemonicEnv = "GO_TESTVEC_MNEMONIC"
+
+func TestMnemonicFromEnvRedacts(t *testing.T) {
+	t.Setenv(testMnemonicEnv, "  "+strings.ToUpper(refMnemonic)+"\n")
+	m, err := MnemonicFromEnv(testMnemonicEnv)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Reveal() != refMnemonic {
+		t.Fatalf("Reveal() = %q, want the normalized phrase", m.Reveal())
+	}
+
+	var logged bytes.Buffer
+	slog.New(slog.NewTextHandler(&logged, nil)).Info("loaded", "mnemonic", m)
+	js, err := json.Marshal(struct{ M RedactedMnemonic }{m})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := []string{logged.String(), string(js)}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+		outputs = append(outputs, fmt.Sprintf(verb, m), fmt.Sprintf(verb, &m), fmt.Sprintf(verb, []any{m}))
+	}
+	for _, out := range outputs {
+		for _, w := range strings.Fields(refMnemonic) {
+			if strings.Contains(out, w) {
+				t.Fatalf("output %q contains mnemonic word %q", out, w)
+			}
+		}
+		if !strings.Contains(out, redacted) {
+			t.Fatalf("output %q is not redacted", out)
+		}
+	}
+}
+
+func TestMnemonicFromEnvErrors(t *testing.T) {
+	t.Setenv(testMnemonicEnv, "tag volcano eight thank tide danger coast health above argue embrace embrace")
+	_, err := MnemonicFromEnv(testMnemonicEnv)
+	if !errors.Is(err, ErrMnemonicChecksum) {
+		t.Fatalf("MnemonicFromEnv() = %v, want %v", err, ErrMnemonicChecksum)
+	}
+	if strings.Contains(err.Error(), "volcano") {
+		t.Fatalf("error %q contains the phrase", err)
+	}
+	if _, err := MnemonicFromEnv("GO_TESTVEC_UNSET_VARIABLE"); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("MnemonicFromEnv(unset) = %v, want %v", err, ErrInvalidMnemonic)
+	}
+}
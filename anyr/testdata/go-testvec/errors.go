@@ -0,0 +1,30 @@
+// Package sentinel errors.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import "errors"
+
+// Every error returned by the package matches, with errors.Is, at most one of
+// the sentinels below or a more specific error documented on the function.
+// The more specific errors wrap these, so callers can branch on either level:
+// ErrMnemonicLength, ErrMnemonicWord and ErrMnemonicChecksum all match
+// ErrInvalidMnemonic.
+var (
+	// ErrInvalidMnemonic is returned when a phrase is not a valid BIP39 mnemonic.
+	ErrInvalidMnemonic = errors.New("invalid mnemonic")
+	// ErrInvalidIndex is returned when an account index is outside 0..2^31-1.
+	ErrInvalidIndex = errors.New("invalid account index")
+	// ErrDerivation is returned when key derivation from a valid mnemonic does
+	// not complete. A cancelled derivation matches both ErrDerivation and the
+	// context's error.
+	ErrDerivation = errors.New("key derivation failed")
+	// ErrEncoding is returned when an account id, master node or other encoded
+	// input cannot be decoded.
+	ErrEncoding = errors.New("invalid encoding")
+	// ErrUnsupportedKeyType is returned when a key is not of a type the
+	// function supports.
+	ErrUnsupportedKeyType = errors.New("unsupported key type")
+)
@@ -0,0 +1,82 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestErrorSentinels(t *testing.T) {
+	const badChecksum = "tag volcano eight thank tide danger coast health above argue embrace embrace"
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	badFixture := filepath.Join(t.TempDir(), "bad.json")
+	if err := os.WriteFile(badFixture, []byte("{"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name string
+		call func() error
+		want error
+	}{
+		{"ValidateMnemonic", func() error { return ValidateMnemonic("tag volcano") }, ErrInvalidMnemonic},
+		{"NormalizeMnemonic", func() error { _, err := NormalizeMnemonic("notaword " + refMnemonic[4:]); return err }, ErrInvalidMnemonic},
+		{"DeriveKeys", func() error { _, err := DeriveKeys(badChecksum, 0); return err }, ErrInvalidMnemonic},
+		{"DeriveKeys index", func() error { _, err := DeriveKeys(refMnemonic, 1<<31); return err }, ErrInvalidIndex},
+		{"DeriveMasterNode", func() error { _, err := DeriveMasterNode(badChecksum, 0); return err }, ErrInvalidMnemonic},
+		{"DeriveMasterNode index", func() error { _, err := DeriveMasterNode(refMnemonic, 1<<31); return err }, ErrInvalidIndex},
+		{"DeriveKeysContext", func() error { _, err := DeriveKeysContext(cancelled, refMnemonic, 0); return err }, ErrDerivation},
+		{"DeriveKeysContext index", func() error { _, err := DeriveKeysContext(context.Background(), refMnemonic, -1); return err }, ErrInvalidIndex},
+		{"DeriveAccountRange", func() error { _, err := DeriveAccountRange(badChecksum, 0, 1); return err }, ErrInvalidMnemonic},
+		{"DeriveAccountRange index", func() error { _, err := DeriveAccountRange(refMnemonic, -1, 1); return err }, ErrInvalidIndex},
+		{"DeriveKeysWithWordlist", func() error { _, err := DeriveKeysWithWordlist(badChecksum, 0, English); return err }, ErrInvalidMnemonic},
+		{"DeriveKeysWithParams", func() error {
+			_, err := DeriveKeysWithParams(context.Background(), badChecksum, 0, DeriveKeysParams{})
+			return err
+		}, ErrInvalidMnemonic},
+		{"GenerateTestVectors", func() error { _, err := GenerateTestVectors([]string{badChecksum}, []int{0}); return err }, ErrInvalidMnemonic},
+		{"AccountToPubKey", func() error {
+			_, err := AccountToPubKey("A9ZJ9CkjFnMLw8Lsgt8gnVTBqhrx1fRPbdCSucdpXxVi78WX")
+			return err
+		}, ErrEncoding},
+		{"UnmarshalMasterNode", func() error { _, err := UnmarshalMasterNode(make([]byte, 32)); return err }, ErrEncoding},
+		{"LoadTestVectors", func() error { _, err := LoadTestVectors(badFixture); return err }, ErrEncoding},
+		{"FileKeyStore.Load", func() error {
+			ks, _ := newTestKeyStore(t)
+			_, err := ks.Load("../escape", nil)
+			return err
+		}, ErrEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.call(); !errors.Is(err, tt.want) {
+				t.Fatalf("got %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestDerivationErrorKeepsCause(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err := DeriveKeysContext(ctx, crypto.Mnemonic(refMnemonic), 0)
+	if !errors.Is(err, ErrDerivation) || !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeriveKeysContext() = %v, want %v wrapping %v", err, ErrDerivation, context.Canceled)
+	}
+}
+
+func TestMnemonicErrorsMatchInvalidMnemonic(t *testing.T) {
+	for _, err := range []error{ErrMnemonicLength, ErrMnemonicWord, ErrMnemonicChecksum, &MnemonicWordError{Index: 3}} {
+		if !errors.Is(err, ErrInvalidMnemonic) {
+			t.Fatalf("%v does not match %v", err, ErrInvalidMnemonic)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+// any-sync handshake credentials for an account identity.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+const (
+	// handshakeProtoVersion is the any-sync secureservice protocol version
+	// advertised in credentials. It must equal secureservice.ProtoVersion, a
+	// var upstream, which TestHandshakeProtoVersionMatchesAnySync checks.
+	handshakeProtoVersion = uint32(8)
+	// minHandshakeNonce is the shortest nonce BuildHandshakeAuth accepts.
+	minHandshakeNonce = 16
+	// handshakeNonceDomain tags the nonce signature so that it can never be
+	// valid as the credential signature over localPeerId + remotePeerId, or as
+	// any other message signed by the identity.
+	handshakeNonceDomain = "anytype-testvec/handshake-nonce/v1"
+)
+
+var (
+	// ErrShortNonce is returned when a handshake nonce is shorter than 16 bytes.
+	ErrShortNonce = errors.New("handshake nonce must be at least 16 bytes")
+	// ErrInvalidHandshake is returned when handshake credentials do not verify.
+	ErrInvalidHandshake = errors.New("invalid handshake credentials")
+)
+
+// handshakeAuth is the message produced by BuildHandshakeAuth.
+//
+// Credentials is exactly what an any-sync peer sends during the secureservice
+// handshake: a handshakeproto.Credentials of type SignedPeerIds whose signature
+// covers PeerID + RemotePeerID. any-sync has no nonce of its own; it relies on
+// the peer ids being authenticated by the transport. The nonce is therefore
+// signed separately in NonceSign, and the peer ids travel alongside so the
+// message can be checked without a live connection.
+type handshakeAuth struct {
+	Credentials  []byte `json:"credentials"`
+	Nonce        []byte `json:"nonce"`
+	NonceSign    []byte `json:"nonce_sign"`
+	PeerID       string `json:"peer_id"`
+	RemotePeerID string `json:"remote_peer_id"`
+}
+
+// BuildHandshakeAuth builds handshake credentials from the node's identity to
+// peerID. The identity key doubles as the local peer key, so the local peer id
+// is the identity's peer id. peerID must be a valid libp2p peer id; otherwise
+// BuildHandshakeAuth fails with ErrEncoding. The nonce must be at least 16
+// bytes and should be issued by the verifier for this handshake only.
+func BuildHandshakeAuth(node *MasterNode, peerID string, nonce []byte) ([]byte, error) {
+	if len(nonce) < minHandshakeNonce {
+		return nil, fmt.Errorf("%w: got %d", ErrShortNonce, len(nonce))
+	}
+	if _, err := peer.Decode(peerID); err != nil {
+		return nil, fmt.Errorf("%w: remote peer id: %w", ErrEncoding, err)
+	}
+	identity, priv := node.identity()
+	defer clear(priv)
+	localPeerID := identity.GetPublic().PeerId()
+
+	identityProto, err := identity.GetPublic().Marshall()
+	if err != nil {
+		return nil, err
+	}
+	sign, err := identity.Sign([]byte(localPeerID + peerID))
+	if err != nil {
+		return nil, err
+	}
+	payload, err := (&handshakeproto.PayloadSignedPeerIds{Identity: identityProto, Sign: sign}).MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	creds, err := (&handshakeproto.Credentials{
+		Type:    handshakeproto.CredentialsType_SignedPeerIds,
+		Payload: payload,
+		Version: handshakeProtoVersion,
+	}).MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	nonceSign, err := identity.Sign(handshakeNonceMessage(localPeerID, peerID, nonce))
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(handshakeAuth{
+		Credentials:  creds,
+		Nonce:        nonce,
+		NonceSign:    nonceSign,
+		PeerID:       localPeerID,
+		RemotePeerID: peerID,
+	})
+}
+
+// VerifyHandshakeAuth checks credentials built by BuildHandshakeAuth and
+// returns the sender's account id. It checks both signatures and the nonce
+// length, but it cannot know which nonces were issued: use
+// VerifyHandshakeAuthNonce to reject replays.
+func VerifyHandshakeAuth(data []byte) (accountID string, err error) {
+	_, pk, err := verifyHandshakeAuth(data)
+	if err != nil {
+		return "", err
+	}
+	return pk.Account(), nil
+}
+
+// VerifyHandshakeAuthNonce is like VerifyHandshakeAuth and also requires the
+// signed nonce to be the one the verifier issued, so a recorded handshake
+// cannot be replayed against a new nonce.
+func VerifyHandshakeAuthNonce(data, nonce []byte) (accountID string, err error) {
+	auth, pk, err := verifyHandshakeAuth(data)
+	if err != nil {
+		return "", err
+	}
+	if subtle.ConstantTimeCompare(auth.Nonce, nonce) != 1 {
+		return "", fmt.Errorf("%w: nonce does not match", ErrInvalidHandshake)
+	}
+	return pk.Account(), nil
+}
+
+func verifyHandshakeAuth(data []byte) (*handshakeAuth, crypto.PubKey, error) {
+	var auth handshakeAuth
+	if err := json.Unmarshal(data, &auth); err != nil {
+		return nil, nil, fmt.Errorf("%w: handshake: %w", ErrEncoding, err)
+	}
+	if len(auth.Nonce) < minHandshakeNonce {
+		return nil, nil, fmt.Errorf("%w: got %d", ErrShortNonce, len(auth.Nonce))
+	}
+	if _, err := peer.Decode(auth.RemotePeerID); err != nil {
+		return nil, nil, fmt.Errorf("%w: remote peer id: %w", ErrInvalidHandshake, err)
+	}
+	var creds handshakeproto.Credentials
+	if err := creds.UnmarshalVT(auth.Credentials); err != nil {
+		return nil, nil, fmt.Errorf("%w: handshake credentials: %w", ErrEncoding, err)
+	}
+	if creds.Type != handshakeproto.CredentialsType_SignedPeerIds {
+		return nil, nil, fmt.Errorf("%w: credentials type %s", ErrInvalidHandshake, creds.Type)
+	}
+	var payload handshakeproto.PayloadSignedPeerIds
+	if err := payload.UnmarshalVT(creds.Payload); err != nil {
+		return nil, nil, fmt.Errorf("%w: handshake payload: %w", ErrEncoding, err)
+	}
+	pk, err := crypto.UnmarshalEd25519PublicKeyProto(payload.Identity)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: handshake identity: %w", ErrEncoding, err)
+	}
+	if pk.PeerId() != auth.PeerID {
+		return nil, nil, fmt.Errorf("%w: peer id is not the identity's", ErrInvalidHandshake)
+	}
+	if ok, err := pk.Verify([]byte(auth.PeerID+auth.RemotePeerID), payload.Sign); err != nil || !ok {
+		return nil, nil, fmt.Errorf("%w: peer id signature", ErrInvalidHandshake)
+	}
+	if ok, err := pk.Verify(handshakeNonceMessage(auth.PeerID, auth.RemotePeerID, auth.Nonce), auth.NonceSign); err != nil || !ok {
+		return nil, nil, fmt.Errorf("%w: nonce signature", ErrInvalidHandshake)
+	}
+	return &auth, pk, nil
+}
+
+// handshakeNonceMessage builds the message signed over the nonce:
+// handshakeNonceDomain followed by the two peer ids and the nonce, each
+// length-prefixed as by domainMessage.
+func handshakeNonceMessage(peerID, remotePeerID string, nonce []byte) []byte {
+	return domainMessage(handshakeNonceDomain, []byte(peerID), []byte(remotePeerID), nonce)
+}
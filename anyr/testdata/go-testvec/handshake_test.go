@@ -0,0 +1,135 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/net/secureservice"
+	"github.com/anyproto/any-sync/net/secureservice/handshake/handshakeproto"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func handshakeFixture(t *testing.T) (node *MasterNode, remotePeerID string, nonce []byte) {
+	t.Helper()
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	remote, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return node, remote.Identity().GetPublic().PeerId(), bytes.Repeat([]byte{0x42}, 16)
+}
+
+func TestHandshakeProtoVersionMatchesAnySync(t *testing.T) {
+	if handshakeProtoVersion != secureservice.ProtoVersion {
+		t.Fatalf("handshakeProtoVersion = %d, any-sync secureservice.ProtoVersion = %d", handshakeProtoVersion, secureservice.ProtoVersion)
+	}
+}
+
+func TestHandshakeAuthRoundTrip(t *testing.T) {
+	node, remotePeerID, nonce := handshakeFixture(t)
+	data, err := BuildHandshakeAuth(node, remotePeerID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, verify := range map[string]func() (string, error){
+		"VerifyHandshakeAuth":      func() (string, error) { return VerifyHandshakeAuth(data) },
+		"VerifyHandshakeAuthNonce": func() (string, error) { return VerifyHandshakeAuthNonce(data, nonce) },
+	} {
+		got, err := verify()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		if got != refAccountID {
+			t.Fatalf("%s = %s, want %s", name, got, refAccountID)
+		}
+	}
+
+	// The embedded credentials verify the way an any-sync peer checks them:
+	// the remote side verifies remotePeerId + localPeerId from its own view.
+	var auth handshakeAuth
+	if err := json.Unmarshal(data, &auth); err != nil {
+		t.Fatal(err)
+	}
+	var creds handshakeproto.Credentials
+	if err := creds.UnmarshalVT(auth.Credentials); err != nil {
+		t.Fatal(err)
+	}
+	var payload handshakeproto.PayloadSignedPeerIds
+	if err := payload.UnmarshalVT(creds.Payload); err != nil {
+		t.Fatal(err)
+	}
+	pk, err := crypto.UnmarshalEd25519PublicKeyProto(payload.Identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	identity, err := node.Identity()
+	if err != nil {
+		t.Fatal(err)
+	}
+	localPeerID := identity.GetPublic().PeerId()
+	if ok, err := pk.Verify([]byte(localPeerID+remotePeerID), payload.Sign); err != nil || !ok {
+		t.Fatal("credentials do not verify as any-sync credentials")
+	}
+	if ok, _ := pk.Verify([]byte(localPeerID+remotePeerID), auth.NonceSign); ok {
+		t.Fatal("nonce signature verifies as a credential signature")
+	}
+}
+
+func TestHandshakeAuthRejects(t *testing.T) {
+	node, remotePeerID, nonce := handshakeFixture(t)
+	if _, err := BuildHandshakeAuth(node, remotePeerID, nonce[:15]); !errors.Is(err, ErrShortNonce) {
+		t.Fatalf("BuildHandshakeAuth(15-byte nonce) = %v, want %v", err, ErrShortNonce)
+	}
+	// A peer id prefix plus a nonce that completes it must not be accepted:
+	// the nonce signature would cover the credential message for the full id.
+	if _, err := BuildHandshakeAuth(node, remotePeerID[:20], []byte(remotePeerID[20:])); !errors.Is(err, ErrEncoding) {
+		t.Fatalf("BuildHandshakeAuth(split peer id) = %v, want %v", err, ErrEncoding)
+	}
+	data, err := BuildHandshakeAuth(node, remotePeerID, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := VerifyHandshakeAuthNonce(data, bytes.Repeat([]byte{0x43}, 16)); !errors.Is(err, ErrInvalidHandshake) {
+		t.Fatalf("replayed nonce = %v, want %v", err, ErrInvalidHandshake)
+	}
+
+	tamper := func(f func(*handshakeAuth)) []byte {
+		var auth handshakeAuth
+		if err := json.Unmarshal(data, &auth); err != nil {
+			t.Fatal(err)
+		}
+		f(&auth)
+		b, err := json.Marshal(auth)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return b
+	}
+	tests := []struct {
+		name string
+		data []byte
+		want error
+	}{
+		{"garbage", []byte("not json"), ErrEncoding},
+		{"short nonce", tamper(func(a *handshakeAuth) { a.Nonce = a.Nonce[:8] }), ErrShortNonce},
+		{"changed nonce", tamper(func(a *handshakeAuth) { a.Nonce = bytes.Repeat([]byte{0x43}, 16) }), ErrInvalidHandshake},
+		{"changed remote", tamper(func(a *handshakeAuth) { a.RemotePeerID += "x" }), ErrInvalidHandshake},
+		{"changed peer id", tamper(func(a *handshakeAuth) { a.PeerID = remotePeerID }), ErrInvalidHandshake},
+		{"bad credentials", tamper(func(a *handshakeAuth) { a.Credentials = []byte{0xff} }), ErrEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := VerifyHandshakeAuth(tt.data); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyHandshakeAuth() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+// Anytype space invite links.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/ipfs/go-cid"
+	"github.com/mr-tron/base58"
+)
+
+// inviteHost is the host of invite links generated by Anytype clients.
+const inviteHost = "invite.any.coop"
+
+// ErrInvalidInvite is returned when an invite link is malformed.
+var ErrInvalidInvite = fmt.Errorf("%w: invalid invite link", ErrEncoding)
+
+// An Anytype invite link has the form
+//
+//	https://invite.any.coop/<cid>#<key>
+//
+// where cid names a file on the Anytype file network and key is the AES key
+// the file is encrypted with, base58 of its raw 32 bytes (not the base64 of
+// crypto.EncodeKeyToString or the multibase of AESKey.String). The fragment
+// is never sent to the web server. The file holds the signed invite payload,
+// including the private half of an invite key that the inviting client
+// generates at random and registers in the space ACL. That key is not derived
+// from the space key or the mnemonic, so an invite can only be created by a
+// client that uploads the file and adds the ACL record; the functions here
+// only format and parse the link.
+
+// FormatInviteLink returns the invite link for the invite file fileCID
+// encrypted with fileKey. fileKey must be an AES key.
+func FormatInviteLink(fileCID string, fileKey crypto.SymKey) (string, error) {
+	if _, err := cid.Decode(fileCID); err != nil {
+		return "", fmt.Errorf("%w: file cid: %w", ErrInvalidInvite, err)
+	}
+	if _, ok := fileKey.(*crypto.AESKey); !ok {
+		return "", fmt.Errorf("%w: invite file key %T", ErrUnsupportedKeyType, fileKey)
+	}
+	raw, err := fileKey.Raw()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	return "https://" + inviteHost + "/" + fileCID + "#" + base58.Encode(raw), nil
+}
+
+// ParseInviteLink returns the invite file cid and file key from an invite
+// link. Malformed links fail with ErrInvalidInvite.
+func ParseInviteLink(link string) (fileCID string, fileKey crypto.SymKey, err error) {
+	u, err := url.Parse(strings.TrimSpace(link))
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrInvalidInvite, err)
+	}
+	if u.Scheme != "https" || u.Host != inviteHost {
+		return "", nil, fmt.Errorf("%w: not an %s link", ErrInvalidInvite, inviteHost)
+	}
+	fileCID = strings.TrimPrefix(u.Path, "/")
+	if _, err := cid.Decode(fileCID); err != nil {
+		return "", nil, fmt.Errorf("%w: file cid: %w", ErrInvalidInvite, err)
+	}
+	raw, err := base58.Decode(u.Fragment)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: file key: %w", ErrInvalidInvite, err)
+	}
+	key, err := crypto.UnmarshallAESKey(raw)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: file key is %d bytes, want %d", ErrInvalidInvite, len(raw), crypto.KeyBytes)
+	}
+	return fileCID, key, nil
+}
@@ -0,0 +1,77 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+const testInviteCID = "bafyreifgd2qzolvr32kt7whaje2r6tkmmej3ar2g2fhqcwsrfqoweyigeu"
+
+func TestInviteLinkRoundTrip(t *testing.T) {
+	raw := bytes.Repeat([]byte{0x42}, crypto.KeyBytes)
+	key, err := crypto.UnmarshallAESKey(raw)
+	if err != nil {
+		t.Fatal(err)
+	}
+	link, err := FormatInviteLink(testInviteCID, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://invite.any.coop/" + testInviteCID + "#" + base58.Encode(raw)
+	if link != want {
+		t.Fatalf("FormatInviteLink() = %s, want %s", link, want)
+	}
+
+	gotCID, gotKey, err := ParseInviteLink(" " + link + "\n")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotCID != testInviteCID || !gotKey.Equals(key) {
+		t.Fatalf("ParseInviteLink() = %s, %v", gotCID, gotKey)
+	}
+
+	// The parsed key decrypts what the original key encrypted.
+	sealed, err := key.Encrypt([]byte("invite"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if opened, err := gotKey.Decrypt(sealed); err != nil || string(opened) != "invite" {
+		t.Fatalf("Decrypt() = %q, %v", opened, err)
+	}
+}
+
+func TestParseInviteLinkRejects(t *testing.T) {
+	key := base58.Encode(bytes.Repeat([]byte{0x42}, crypto.KeyBytes))
+	tests := []struct {
+		name string
+		link string
+	}{
+		{"empty", ""},
+		{"http", "http://invite.any.coop/" + testInviteCID + "#" + key},
+		{"other host", "https://example.com/" + testInviteCID + "#" + key},
+		{"bad cid", "https://invite.any.coop/not-a-cid#" + key},
+		{"nested path", "https://invite.any.coop/x/" + testInviteCID + "#" + key},
+		{"no key", "https://invite.any.coop/" + testInviteCID},
+		{"short key", "https://invite.any.coop/" + testInviteCID + "#" + key[:len(key)-4]},
+		{"not base58", "https://invite.any.coop/" + testInviteCID + "#" + strings.Repeat("0", 44)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, _, err := ParseInviteLink(tt.link); !errors.Is(err, ErrInvalidInvite) || !errors.Is(err, ErrEncoding) {
+				t.Fatalf("ParseInviteLink(%q) = %v, want %v", tt.link, err, ErrInvalidInvite)
+			}
+		})
+	}
+
+	if _, err := FormatInviteLink("not-a-cid", crypto.NewAES()); !errors.Is(err, ErrInvalidInvite) {
+		t.Fatalf("FormatInviteLink(bad cid) = %v, want %v", err, ErrInvalidInvite)
+	}
+}
@@ -0,0 +1,78 @@
+// Iteration over the accounts of a mnemonic.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// ErrNoMoreAccounts is returned by AccountIterator.Next after the last
+// account index, 2^31-1.
+var ErrNoMoreAccounts = errors.New("no more account indices")
+
+// AccountRoot holds the parents of every account node of a mnemonic,
+// m/44'/2046' and m/44'/607'. A MasterNode is a single account's node and
+// cannot reach its siblings, so iteration starts here instead.
+type AccountRoot struct {
+	prefixes *accountPrefixes
+}
+
+// DeriveAccountRoot validates the mnemonic and derives its account root. This
+// is the only step that runs PBKDF2; accounts below the root are cheap.
+func DeriveAccountRoot(m crypto.Mnemonic) (*AccountRoot, error) {
+	start := time.Now()
+	prefixes, err := deriveAccountPrefixes(context.Background(), m)
+	logDerivation("DeriveAccountRoot", start, err)
+	if err != nil {
+		return nil, err
+	}
+	return &AccountRoot{prefixes: prefixes}, nil
+}
+
+// Accounts returns an iterator over the accounts from index 0.
+func (r *AccountRoot) Accounts() *AccountIterator {
+	return &AccountIterator{root: r}
+}
+
+// Wipe overwrites the root's nodes with zeros. Iterators of the root must not
+// be used afterwards; results they already returned are unaffected.
+func (r *AccountRoot) Wipe() {
+	r.prefixes.wipe()
+}
+
+// AccountIterator yields the accounts of an AccountRoot in index order:
+//
+//	it := root.Accounts()
+//	for acct, err := it.Next(); err == nil; acct, err = it.Next() {
+//		...
+//	}
+//
+// Each result owns its buffers and may be wiped independently. An iterator is
+// not safe for concurrent use.
+type AccountIterator struct {
+	root *AccountRoot
+	next uint32
+	done bool
+}
+
+// Next returns the next account, or ErrNoMoreAccounts once index 2^31-1 has
+// been returned.
+func (it *AccountIterator) Next() (*KeyResult, error) {
+	if it.done {
+		return nil, ErrNoMoreAccounts
+	}
+	res := it.root.prefixes.keys(it.next)
+	if it.next == firstHardenedIndex-1 {
+		it.done = true
+	} else {
+		it.next++
+	}
+	return res, nil
+}
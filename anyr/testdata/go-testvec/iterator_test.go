@@ -0,0 +1,64 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestAccountIterator(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	root, err := DeriveAccountRoot(m)
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := root.Accounts()
+	var got []*KeyResult
+	for acct, err := it.Next(); err == nil && len(got) < 5; acct, err = it.Next() {
+		got = append(got, acct)
+	}
+	root.Wipe()
+
+	for i, acct := range got {
+		want, err := DeriveKeys(m, uint32(i))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if acct.Index != uint32(i) || acct.AccountID != want.AccountID {
+			t.Fatalf("account %d: got index %d id %s, want %s", i, acct.Index, acct.AccountID, want.AccountID)
+		}
+		if !acct.Identity().Equals(want.Identity()) || !acct.OldAccountKey().Equals(want.OldAccountKey()) {
+			t.Fatalf("account %d: keys differ from DeriveKeys", i)
+		}
+	}
+	// Results do not alias each other or the wiped root.
+	got[0].Wipe()
+	if id, _ := got[1].MasterNode().AccountID(); id != got[1].AccountID {
+		t.Fatal("wiping one result affected another")
+	}
+}
+
+func TestAccountIteratorCeiling(t *testing.T) {
+	root, err := DeriveAccountRoot(crypto.Mnemonic(refMnemonic))
+	if err != nil {
+		t.Fatal(err)
+	}
+	it := root.Accounts()
+	it.next = firstHardenedIndex - 1
+	last, err := it.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if last.Index != 1<<31-1 {
+		t.Fatalf("last index = %d, want %d", last.Index, 1<<31-1)
+	}
+	for range 2 {
+		if _, err := it.Next(); !errors.Is(err, ErrNoMoreAccounts) {
+			t.Fatalf("Next() past ceiling = %v, want %v", err, ErrNoMoreAccounts)
+		}
+	}
+}
@@ -0,0 +1,76 @@
+// Pluggable PBKDF2 backend for seed expansion.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+)
+
+// seedLen is the length of a BIP39 seed.
+const seedLen = 64
+
+// KDF computes BIP39 seeds. DeriveSeed must return the 64-byte
+// PBKDF2-HMAC-SHA512 of mnemonic with salt "mnemonic"+passphrase and the
+// given iteration count, exactly as the default implementation does; only
+// the speed may differ. It must be safe for concurrent use.
+type KDF interface {
+	DeriveSeed(mnemonic, passphrase []byte, iterations int) []byte
+}
+
+// pureGoKDF is the default KDF.
+type pureGoKDF struct{}
+
+func (pureGoKDF) DeriveSeed(mnemonic, passphrase []byte, iterations int) []byte {
+	salt := append([]byte("mnemonic"), passphrase...)
+	seed, _ := pbkdf2SHA512(context.Background(), mnemonic, salt, iterations)
+	return seed
+}
+
+// DefaultKDF returns the pure-Go KDF the package uses unless SetKDF is called.
+func DefaultKDF() KDF { return pureGoKDF{} }
+
+type kdfHolder struct{ KDF }
+
+var kdf atomic.Pointer[kdfHolder]
+
+func init() { SetKDF(nil) }
+
+// SetKDF makes every seed expansion in the package use k. A nil k restores
+// the default. It is safe to call concurrently with derivation.
+//
+// The default checks for context cancellation between rounds. An alternate
+// KDF cannot be interrupted, so cancellation is only observed before and
+// after it runs.
+func SetKDF(k KDF) {
+	if k == nil {
+		k = pureGoKDF{}
+	}
+	kdf.Store(&kdfHolder{k})
+}
+
+// deriveSeed expands a validated phrase into its seed with the current KDF.
+// A KDF that returns anything but 64 bytes fails with ErrDerivation.
+func deriveSeed(ctx context.Context, phrase, passphrase []byte, iterations int) ([]byte, error) {
+	k := kdf.Load().KDF
+	if _, ok := k.(pureGoKDF); ok {
+		return pbkdf2SHA512(ctx, phrase, append([]byte("mnemonic"), passphrase...), iterations)
+	}
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+	}
+	seed := k.DeriveSeed(phrase, passphrase, iterations)
+	if err := ctx.Err(); err != nil {
+		clear(seed)
+		return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+	}
+	if len(seed) != seedLen {
+		clear(seed)
+		return nil, fmt.Errorf("%w: KDF returned %d bytes, want %d", ErrDerivation, len(seed), seedLen)
+	}
+	return seed, nil
+}
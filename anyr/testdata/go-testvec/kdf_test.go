@@ -0,0 +1,89 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"context"
+	"crypto/pbkdf2"
+	"crypto/sha512"
+	"errors"
+	"sync/atomic"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// stdlibKDF is an alternate KDF backed by crypto/pbkdf2.
+type stdlibKDF struct{ calls *atomic.Int32 }
+
+func (k stdlibKDF) DeriveSeed(mnemonic, passphrase []byte, iterations int) []byte {
+	k.calls.Add(1)
+	seed, err := pbkdf2.Key(sha512.New, string(mnemonic), append([]byte("mnemonic"), passphrase...), iterations, seedLen)
+	if err != nil {
+		panic(err)
+	}
+	return seed
+}
+
+type shortKDF struct{}
+
+func (shortKDF) DeriveSeed([]byte, []byte, int) []byte { return make([]byte, 32) }
+
+func TestKDFConformance(t *testing.T) {
+	t.Cleanup(func() { SetKDF(nil) })
+	want := DefaultKDF().DeriveSeed([]byte(refMnemonic), nil, pbkdf2Iterations)
+	wantTrezor := DefaultKDF().DeriveSeed([]byte(refMnemonic), []byte("TREZOR"), pbkdf2Iterations)
+
+	alt := stdlibKDF{calls: new(atomic.Int32)}
+	if got := alt.DeriveSeed([]byte(refMnemonic), nil, pbkdf2Iterations); !bytes.Equal(got, want) {
+		t.Fatalf("alternate seed %x, default %x", got, want)
+	}
+	if got := alt.DeriveSeed([]byte(refMnemonic), []byte("TREZOR"), pbkdf2Iterations); !bytes.Equal(got, wantTrezor) {
+		t.Fatal("alternate seed with passphrase differs from default")
+	}
+
+	SetKDF(alt)
+	alt.calls.Store(0)
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AccountID != refAccountID {
+		t.Fatalf("account id with alternate KDF = %s, want %s", res.AccountID, refAccountID)
+	}
+	seed, err := Seed(refMnemonic, "TREZOR")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(seed, wantTrezor) {
+		t.Fatal("Seed with alternate KDF differs from default")
+	}
+	if got := alt.calls.Load(); got != 2 {
+		t.Fatalf("alternate KDF called %d times, want 2", got)
+	}
+
+	SetKDF(nil)
+	if _, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0); err != nil {
+		t.Fatal(err)
+	}
+	if got := alt.calls.Load(); got != 2 {
+		t.Fatal("SetKDF(nil) did not restore the default")
+	}
+}
+
+func TestKDFErrors(t *testing.T) {
+	t.Cleanup(func() { SetKDF(nil) })
+	SetKDF(shortKDF{})
+	if _, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0); !errors.Is(err, ErrDerivation) {
+		t.Fatalf("DeriveKeys with short KDF output = %v, want %v", err, ErrDerivation)
+	}
+
+	SetKDF(stdlibKDF{calls: new(atomic.Int32)})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err := DeriveKeysContext(ctx, crypto.Mnemonic(refMnemonic), 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeriveKeysContext(cancelled) = %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,244 @@
+// Encrypted at-rest storage for account master nodes.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/chacha20poly1305"
+)
+
+var (
+	// ErrBadPassphrase is returned by Load when the keystore entry cannot be
+	// decrypted. An AEAD cannot tell a wrong passphrase from a tampered
+	// ciphertext, so both surface as this error.
+	ErrBadPassphrase = errors.New("keystore: bad passphrase")
+	// ErrCorruptKeystore is returned by Load when an entry is structurally invalid.
+	ErrCorruptKeystore = errors.New("keystore: corrupt entry")
+	// ErrInvalidKDFParams is returned for Argon2id parameters outside the
+	// bounds that Save writes and Load will run.
+	ErrInvalidKDFParams = errors.New("keystore: kdf params out of range")
+)
+
+// KeyStore persists account master nodes encrypted under a passphrase.
+type KeyStore interface {
+	Save(accountID string, node *MasterNode, passphrase []byte) error
+	Load(accountID string, passphrase []byte) (*MasterNode, error)
+}
+
+// KDFParams are the Argon2id parameters used to derive the wrapping key.
+type KDFParams struct {
+	Time      uint32 `json:"time"`
+	MemoryKiB uint32 `json:"memory_kib"`
+	Threads   uint8  `json:"threads"`
+}
+
+// DefaultKDFParams follows the RFC 9106 second recommended option.
+var DefaultKDFParams = KDFParams{Time: 3, MemoryKiB: 64 * 1024, Threads: 4}
+
+const (
+	keystoreVersion = 1
+	keystoreKDF     = "argon2id"
+	keystoreCipher  = "xchacha20-poly1305"
+	keystoreSaltLen = 16
+	// The max KDF params bound the work a stored entry can ask Load to do.
+	// They leave headroom above DefaultKDFParams without letting a tampered
+	// file hang Load or exhaust memory.
+	maxKDFTime      = 16
+	maxKDFMemoryKiB = 1024 * 1024
+	maxKDFThreads   = 16
+)
+
+// check reports whether p is within the bounds Load accepts.
+func (p KDFParams) check() error {
+	if p.Time == 0 || p.Time > maxKDFTime ||
+		p.MemoryKiB == 0 || p.MemoryKiB > maxKDFMemoryKiB ||
+		p.Threads == 0 || p.Threads > maxKDFThreads {
+		return fmt.Errorf("%w: time %d, memory %d KiB, threads %d", ErrInvalidKDFParams, p.Time, p.MemoryKiB, p.Threads)
+	}
+	return nil
+}
+
+// keystoreEntry is the on-disk JSON format. The KDF parameters are stored with
+// each entry so the defaults can change without breaking existing files.
+type keystoreEntry struct {
+	Version    int       `json:"version"`
+	KDF        string    `json:"kdf"`
+	KDFParams  KDFParams `json:"kdf_params"`
+	Salt       []byte    `json:"salt"`
+	Cipher     string    `json:"cipher"`
+	Nonce      []byte    `json:"nonce"`
+	Ciphertext []byte    `json:"ciphertext"`
+}
+
+// FileKeyStore stores one JSON file per account in a directory.
+type FileKeyStore struct {
+	dir string
+	// Params is used for new entries. Existing entries use the params they were saved with.
+	Params KDFParams
+}
+
+var _ KeyStore = (*FileKeyStore)(nil)
+
+// NewFileKeyStore returns a keystore rooted at dir, using DefaultKDFParams.
+func NewFileKeyStore(dir string) *FileKeyStore {
+	return &FileKeyStore{dir: dir, Params: DefaultKDFParams}
+}
+
+// Save encrypts node under passphrase and writes it to the entry for accountID.
+// The account id is bound to the ciphertext as associated data. accountID must
+// be the node's own account id; otherwise Save fails with ErrAccountMismatch
+// and writes nothing.
+func (s *FileKeyStore) Save(accountID string, node *MasterNode, passphrase []byte) error {
+	path, err := s.entryPath(accountID)
+	if err != nil {
+		return err
+	}
+	nodeAccountID, err := node.AccountID()
+	if err != nil {
+		return err
+	}
+	if nodeAccountID != accountID {
+		return ErrAccountMismatch
+	}
+	if err := s.Params.check(); err != nil {
+		return err
+	}
+	plaintext, err := node.MarshalBinary()
+	if err != nil {
+		return err
+	}
+	defer clear(plaintext)
+
+	entry := keystoreEntry{
+		Version:   keystoreVersion,
+		KDF:       keystoreKDF,
+		KDFParams: s.Params,
+		Salt:      make([]byte, keystoreSaltLen),
+		Cipher:    keystoreCipher,
+		Nonce:     make([]byte, chacha20poly1305.NonceSizeX),
+	}
+	if _, err := rand.Read(entry.Salt); err != nil {
+		return err
+	}
+	if _, err := rand.Read(entry.Nonce); err != nil {
+		return err
+	}
+	key := wrappingKey(passphrase, entry.Salt, entry.KDFParams)
+	defer clear(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return err
+	}
+	entry.Ciphertext = aead.Seal(nil, entry.Nonce, plaintext, []byte(accountID))
+
+	data, err := json.MarshalIndent(entry, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(s.dir, 0o700); err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(s.dir, ".keystore-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	if _, err := tmp.Write(append(data, '\n')); err != nil {
+		tmp.Close()
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		return err
+	}
+	return os.Rename(tmp.Name(), path)
+}
+
+// Load decrypts the entry for accountID. It returns ErrBadPassphrase if
+// decryption fails and ErrCorruptKeystore if the entry is malformed or does not
+// belong to accountID.
+func (s *FileKeyStore) Load(accountID string, passphrase []byte) (*MasterNode, error) {
+	path, err := s.entryPath(accountID)
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var entry keystoreEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptKeystore, err)
+	}
+	if err := entry.check(); err != nil {
+		return nil, err
+	}
+
+	key := wrappingKey(passphrase, entry.Salt, entry.KDFParams)
+	defer clear(key)
+	aead, err := chacha20poly1305.NewX(key)
+	if err != nil {
+		return nil, err
+	}
+	plaintext, err := aead.Open(nil, entry.Nonce, entry.Ciphertext, []byte(accountID))
+	if err != nil {
+		return nil, ErrBadPassphrase
+	}
+	defer clear(plaintext)
+
+	node, err := UnmarshalMasterNode(plaintext)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrCorruptKeystore, err)
+	}
+	got, err := node.AccountID()
+	if err != nil {
+		return nil, err
+	}
+	if got != accountID {
+		return nil, fmt.Errorf("%w: entry holds a different account", ErrCorruptKeystore)
+	}
+	return node, nil
+}
+
+// entryPath returns the file for accountID, rejecting ids that are not valid
+// account ids so they cannot be used to escape the keystore directory.
+func (s *FileKeyStore) entryPath(accountID string) (string, error) {
+	if _, err := AccountToPubKey(accountID); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, accountID+".json"), nil
+}
+
+// check validates the structure of an entry before any KDF work is done.
+func (e *keystoreEntry) check() error {
+	switch {
+	case e.Version != keystoreVersion:
+		return fmt.Errorf("%w: unsupported version %d", ErrCorruptKeystore, e.Version)
+	case e.KDF != keystoreKDF || e.Cipher != keystoreCipher:
+		return fmt.Errorf("%w: unsupported kdf %q or cipher %q", ErrCorruptKeystore, e.KDF, e.Cipher)
+	case len(e.Salt) < keystoreSaltLen:
+		return fmt.Errorf("%w: salt too short", ErrCorruptKeystore)
+	case len(e.Nonce) != chacha20poly1305.NonceSizeX:
+		return fmt.Errorf("%w: bad nonce length", ErrCorruptKeystore)
+	case len(e.Ciphertext) != masterNodeLen+chacha20poly1305.Overhead:
+		return fmt.Errorf("%w: bad ciphertext length", ErrCorruptKeystore)
+	}
+	if err := e.KDFParams.check(); err != nil {
+		return fmt.Errorf("%w: %w", ErrCorruptKeystore, err)
+	}
+	return nil
+}
+
+// wrappingKey derives the entry encryption key from the passphrase.
+func wrappingKey(passphrase, salt []byte, p KDFParams) []byte {
+	return argon2.IDKey(passphrase, salt, p.Time, p.MemoryKiB, p.Threads, chacha20poly1305.KeySize)
+}
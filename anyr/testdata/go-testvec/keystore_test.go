@@ -0,0 +1,147 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"math"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// testKDFParams keeps Argon2id cheap so the tests run quickly.
+var testKDFParams = KDFParams{Time: 1, MemoryKiB: 64, Threads: 1}
+
+func newTestKeyStore(t *testing.T) (*FileKeyStore, *MasterNode) {
+	t.Helper()
+	store := NewFileKeyStore(t.TempDir())
+	store.Params = testKDFParams
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(refAccountID, node, []byte("correct horse")); err != nil {
+		t.Fatal(err)
+	}
+	return store, node
+}
+
+func TestFileKeyStoreRoundTrip(t *testing.T) {
+	store, node := newTestKeyStore(t)
+	loaded, err := store.Load(refAccountID, []byte("correct horse"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := node.MarshalBinary()
+	got, _ := loaded.MarshalBinary()
+	if !bytes.Equal(got, want) {
+		t.Fatal("loaded node differs from saved node")
+	}
+	info, err := os.Stat(filepath.Join(store.dir, refAccountID+".json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if perm := info.Mode().Perm(); perm&0o077 != 0 {
+		t.Fatalf("keystore entry has permissions %o", perm)
+	}
+}
+
+func TestFileKeyStoreBadPassphrase(t *testing.T) {
+	store, _ := newTestKeyStore(t)
+	if _, err := store.Load(refAccountID, []byte("wrong")); !errors.Is(err, ErrBadPassphrase) {
+		t.Fatalf("Load() = %v, want %v", err, ErrBadPassphrase)
+	}
+}
+
+func TestFileKeyStoreCorrupt(t *testing.T) {
+	store, _ := newTestKeyStore(t)
+	path := filepath.Join(store.dir, refAccountID+".json")
+	if err := os.WriteFile(path, []byte(`{"version":1`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(refAccountID, []byte("correct horse")); !errors.Is(err, ErrCorruptKeystore) {
+		t.Fatalf("Load() = %v, want %v", err, ErrCorruptKeystore)
+	}
+	if err := os.WriteFile(path, []byte(`{"version":2}`), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := store.Load(refAccountID, []byte("correct horse")); !errors.Is(err, ErrCorruptKeystore) {
+		t.Fatalf("Load() = %v, want %v", err, ErrCorruptKeystore)
+	}
+}
+
+func TestFileKeyStoreRejectsInvalidAccountID(t *testing.T) {
+	store, node := newTestKeyStore(t)
+	if err := store.Save("../escape", node, []byte("x")); err == nil {
+		t.Fatal("Save accepted an invalid account id")
+	}
+}
+
+func TestFileKeyStoreSaveRejectsMismatchedAccount(t *testing.T) {
+	store, _ := newTestKeyStore(t)
+	other, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Save(refAccountID, other, []byte("x")); !errors.Is(err, ErrAccountMismatch) {
+		t.Fatalf("Save(other node) = %v, want %v", err, ErrAccountMismatch)
+	}
+	// The existing entry is left as it was.
+	if _, err := store.Load(refAccountID, []byte("correct horse")); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestFileKeyStoreRejectsKDFParamsOutOfRange(t *testing.T) {
+	store, _ := newTestKeyStore(t)
+	path := filepath.Join(store.dir, refAccountID+".json")
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Each case would take hours or gigabytes if Load ran the KDF.
+	tests := []struct {
+		name   string
+		params KDFParams
+	}{
+		{"time", KDFParams{Time: math.MaxUint32, MemoryKiB: 64, Threads: 1}},
+		{"memory", KDFParams{Time: 1, MemoryKiB: math.MaxUint32, Threads: 1}},
+		{"threads", KDFParams{Time: 1, MemoryKiB: 64, Threads: math.MaxUint8}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var entry map[string]any
+			if err := json.Unmarshal(data, &entry); err != nil {
+				t.Fatal(err)
+			}
+			entry["kdf_params"] = tt.params
+			b, err := json.Marshal(entry)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := os.WriteFile(path, b, 0o600); err != nil {
+				t.Fatal(err)
+			}
+			_, err = store.Load(refAccountID, []byte("correct horse"))
+			if !errors.Is(err, ErrCorruptKeystore) || !errors.Is(err, ErrInvalidKDFParams) {
+				t.Fatalf("Load() = %v, want %v and %v", err, ErrCorruptKeystore, ErrInvalidKDFParams)
+			}
+
+			s := NewFileKeyStore(t.TempDir())
+			s.Params = tt.params
+			node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := s.Save(refAccountID, node, []byte("x")); !errors.Is(err, ErrInvalidKDFParams) {
+				t.Fatalf("Save() = %v, want %v", err, ErrInvalidKDFParams)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+// Pre-any-sync (legacy) Anytype account ids.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// Legacy and current account ids use the same encoding, the strkey form
+// described in account.go. What differs is the key:
+//
+//	legacy:  key of m/44'/607'/index'        (the old account key itself)
+//	current: key of m/44'/2046'/index'/0'    (the identity below the master node)
+//
+// The pre-any-sync middleware derived accounts under m/44'/607'; any-sync keeps
+// that key as OldAccountKey so data from those accounts can still be matched,
+// and the legacy id is its Account(). The 607 node is on
+// a separate hardened branch of the seed, so it cannot be derived from a
+// MasterNode; the mnemonic is required, which is why there is no
+// MasterNode.LegacyAccountID.
+
+// LegacyAccountID returns the pre-any-sync account id of the result's account.
+func (r *KeyResult) LegacyAccountID() string {
+	return r.OldAccountKey().GetPublic().Account()
+}
+
+// DeriveLegacyAccountID validates the mnemonic and returns the legacy account
+// id for index.
+func DeriveLegacyAccountID(m crypto.Mnemonic, index int) (string, error) {
+	res, err := DeriveKeysContext(context.Background(), m, index)
+	if err != nil {
+		return "", err
+	}
+	defer res.Wipe()
+	return res.LegacyAccountID(), nil
+}
@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/go-slip10"
+)
+
+// refLegacyAccountID is the legacy account id of refMnemonic, index 0.
+const refLegacyAccountID = "A9a2cNgtGhgjigJ1Kic6LKSpL54t6PGwK2jxhJ2Yyzt52nmq"
+
+func TestLegacyAccountIDReference(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	res, err := DeriveKeys(m, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AccountID != refAccountID {
+		t.Fatalf("current account id = %s, want %s", res.AccountID, refAccountID)
+	}
+	if got := res.LegacyAccountID(); got != refLegacyAccountID {
+		t.Fatalf("legacy account id = %s, want %s", got, refLegacyAccountID)
+	}
+	if got, err := DeriveLegacyAccountID(m, 0); err != nil || got != refLegacyAccountID {
+		t.Fatalf("DeriveLegacyAccountID() = %s, %v, want %s", got, err, refLegacyAccountID)
+	}
+
+	// The legacy id is the strkey of the m/44'/607'/0' node key, derived here
+	// independently with go-slip10.
+	seed, err := m.Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	node, err := slip10.DeriveForPath("m/44'/607'/0'", seed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, pub, err := crypto.GenerateEd25519Key(bytes.NewReader(node.RawSeed()))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := pub.Account(); got != refLegacyAccountID {
+		t.Fatalf("m/44'/607'/0' account = %s, want %s", got, refLegacyAccountID)
+	}
+}
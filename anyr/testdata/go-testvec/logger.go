@@ -0,0 +1,56 @@
+// Pluggable logging of derivation events.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"sync/atomic"
+	"time"
+)
+
+// Logger receives derivation events. keyvals alternate keys and values, as in
+// log/slog; a *slog.Logger satisfies Logger directly. The package only ever
+// logs non-secret metadata: operation names, account indices, durations,
+// account ids and errors, none of which include phrase words or key bytes.
+type Logger interface {
+	Debug(msg string, keyvals ...any)
+	Info(msg string, keyvals ...any)
+	Warn(msg string, keyvals ...any)
+}
+
+type nopLogger struct{}
+
+func (nopLogger) Debug(string, ...any) {}
+func (nopLogger) Info(string, ...any)  {}
+func (nopLogger) Warn(string, ...any)  {}
+
+type loggerHolder struct{ Logger }
+
+var logger atomic.Pointer[loggerHolder]
+
+func init() { SetLogger(nil) }
+
+// SetLogger routes the package's log events to l: one event for every call
+// to a function that runs the seed derivation. A nil l discards them, which is
+// the default. It is safe to call concurrently with derivation.
+func SetLogger(l Logger) {
+	if l == nil {
+		l = nopLogger{}
+	}
+	logger.Store(&loggerHolder{l})
+}
+
+// logDerivation reports the outcome of a derivation that started at start, as
+// a Debug event on success or a Warn event with err on failure. Callers pass
+// only non-secret fields in keyvals.
+func logDerivation(op string, start time.Time, err error, keyvals ...any) {
+	keyvals = append([]any{"op", op, "duration", time.Since(start)}, keyvals...)
+	l := logger.Load().Logger
+	if err != nil {
+		l.Warn("derivation failed", append(keyvals, "error", err)...)
+		return
+	}
+	l.Debug("derivation done", keyvals...)
+}
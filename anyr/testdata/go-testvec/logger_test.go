@@ -0,0 +1,145 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+type logEntry struct {
+	level, msg string
+	keyvals    []any
+}
+
+type captureLogger struct {
+	mu      sync.Mutex
+	entries []logEntry
+}
+
+func (c *captureLogger) log(level, msg string, keyvals []any) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.entries = append(c.entries, logEntry{level, msg, keyvals})
+}
+
+func (c *captureLogger) Debug(msg string, keyvals ...any) { c.log("debug", msg, keyvals) }
+func (c *captureLogger) Info(msg string, keyvals ...any)  { c.log("info", msg, keyvals) }
+func (c *captureLogger) Warn(msg string, keyvals ...any)  { c.log("warn", msg, keyvals) }
+
+func TestLoggerOmitsSecrets(t *testing.T) {
+	logs := &captureLogger{}
+	SetLogger(logs)
+	t.Cleanup(func() { SetLogger(nil) })
+
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := DeriveAccountRange(crypto.Mnemonic(refMnemonic), 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	// Failures must not echo the phrase either.
+	words := strings.Fields(refMnemonic)
+	words[0], words[1] = words[1], words[0]
+	if _, err := DeriveKeys(crypto.Mnemonic(strings.Join(words, " ")), 0); err == nil {
+		t.Fatal("DeriveKeys with swapped words succeeded")
+	}
+	if _, err := DeriveKeys(crypto.Mnemonic(refMnemonic+" zzz"), 0); err == nil {
+		t.Fatal("DeriveKeys with an extra word succeeded")
+	}
+
+	var secrets []string
+	for _, key := range []crypto.PrivKey{res.MasterKey(), res.OldAccountKey(), res.Identity()} {
+		raw, err := key.Raw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		secrets = append(secrets, hex.EncodeToString(raw[:32]), base64.StdEncoding.EncodeToString(raw[:32]), string(raw[:32]))
+	}
+
+	var levels []string
+	for _, e := range logs.entries {
+		levels = append(levels, e.level)
+		fields := append([]any{e.msg}, e.keyvals...)
+		for _, f := range fields {
+			s := fmt.Sprint(f)
+			for _, w := range strings.Fields(refMnemonic) {
+				if strings.Contains(s, w) {
+					t.Fatalf("%s %q: field %q contains mnemonic word %q", e.level, e.msg, s, w)
+				}
+			}
+			for _, secret := range secrets {
+				if strings.Contains(s, secret) {
+					t.Fatalf("%s %q: field %q contains private key bytes", e.level, e.msg, s)
+				}
+			}
+		}
+	}
+	if got, want := strings.Join(levels, ","), "debug,debug,warn,warn"; got != want {
+		t.Fatalf("log levels = %s, want %s", got, want)
+	}
+	if !containsField(logs.entries[0].keyvals, "account_id", refAccountID) {
+		t.Fatalf("first event %v lacks the account id", logs.entries[0].keyvals)
+	}
+}
+
+func containsField(keyvals []any, key string, value any) bool {
+	for i := 0; i+1 < len(keyvals); i += 2 {
+		if keyvals[i] == key && keyvals[i+1] == value {
+			return true
+		}
+	}
+	return false
+}
+
+func TestEveryDerivationLogs(t *testing.T) {
+	m := crypto.Mnemonic(refMnemonic)
+	derive := []struct {
+		op   string
+		call func(crypto.Mnemonic) error
+	}{
+		{"DeriveKeys", func(m crypto.Mnemonic) error { _, err := DeriveKeys(m, 0); return err }},
+		{"DeriveKeys", func(m crypto.Mnemonic) error { _, err := DeriveKeysContext(context.Background(), m, 0); return err }},
+		{"DeriveAccountRange", func(m crypto.Mnemonic) error { _, err := DeriveAccountRange(m, 0, 1); return err }},
+		{"DeriveMasterNode", func(m crypto.Mnemonic) error { _, err := DeriveMasterNode(m, 0); return err }},
+		{"DeriveAccountRoot", func(m crypto.Mnemonic) error { _, err := DeriveAccountRoot(m); return err }},
+		{"DeriveKeysWithParams", func(m crypto.Mnemonic) error {
+			_, err := DeriveKeysWithParams(context.Background(), m, 0, DeriveKeysParams{})
+			return err
+		}},
+		{"DeriveKeysWithWordlist", func(m crypto.Mnemonic) error { _, err := DeriveKeysWithWordlist(string(m), 0, English); return err }},
+		{"Seed", func(m crypto.Mnemonic) error { _, err := Seed(m, ""); return err }},
+	}
+	for _, d := range derive {
+		for _, tt := range []struct {
+			m     crypto.Mnemonic
+			level string
+		}{
+			{m, "debug"},
+			{m + " zzz", "warn"},
+		} {
+			logs := &captureLogger{}
+			SetLogger(logs)
+			err := d.call(tt.m)
+			SetLogger(nil)
+			if (err != nil) != (tt.level == "warn") {
+				t.Fatalf("%s: unexpected error %v", d.op, err)
+			}
+			if len(logs.entries) != 1 {
+				t.Fatalf("%s: %d log events, want 1", d.op, len(logs.entries))
+			}
+			if e := logs.entries[0]; e.level != tt.level || !containsField(e.keyvals, "op", d.op) {
+				t.Fatalf("%s: got %s event %v, want %s with op %s", d.op, e.level, e.keyvals, tt.level, d.op)
+			}
+		}
+	}
+}
@@ -0,0 +1,128 @@
+// SLIP-10 Ed25519 nodes and master node serialization.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/ed25519"
+	"crypto/hmac"
+	"crypto/sha512"
+	"encoding/binary"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+const (
+	// masterNodeLen is the size of a serialized node: key[32] || chain_code[32].
+	masterNodeLen = 64
+	// firstHardenedIndex is the SLIP-10 hardened flag. Ed25519 only supports
+	// hardened derivation, so every child index has it set.
+	firstHardenedIndex = uint32(0x80000000)
+	// slip10Curve is the HMAC key used to derive the SLIP-10 root for Ed25519.
+	slip10Curve = "ed25519 seed"
+)
+
+// extendedKey is a SLIP-10 Ed25519 node. It is computed here rather than with
+// go-slip10 so that the package owns the key and chain code and can wipe them;
+// TestSlip10MatchesGoSlip10 checks the two agree.
+type extendedKey struct {
+	key       []byte
+	chainCode []byte
+}
+
+// slip10Derive derives the node at the hardened path (indices without the
+// hardened flag) from a BIP39 seed. Intermediate nodes are wiped.
+func slip10Derive(seed []byte, path []uint32) *extendedKey {
+	mac := hmac.New(sha512.New, []byte(slip10Curve))
+	mac.Write(seed)
+	k := splitExtendedKey(mac.Sum(nil))
+	for _, i := range path {
+		next := k.child(i)
+		k.wipe()
+		k = next
+	}
+	return k
+}
+
+// splitExtendedKey splits an HMAC-SHA512 output into key and chain code.
+func splitExtendedKey(sum []byte) *extendedKey {
+	return &extendedKey{key: sum[:32:32], chainCode: sum[32:]}
+}
+
+// child derives the hardened child i'.
+func (k *extendedKey) child(i uint32) *extendedKey {
+	var data [1 + 32 + 4]byte
+	copy(data[1:33], k.key)
+	binary.BigEndian.PutUint32(data[33:], firstHardenedIndex|i)
+	mac := hmac.New(sha512.New, k.chainCode)
+	mac.Write(data[:])
+	clear(data[:])
+	return splitExtendedKey(mac.Sum(nil))
+}
+
+// privKey returns the Ed25519 key seeded by the node key, as any-sync derives
+// it, along with the private key bytes backing it so the caller can wipe them.
+func (k *extendedKey) privKey() (crypto.PrivKey, []byte) {
+	priv := ed25519.NewKeyFromSeed(k.key)
+	return crypto.NewEd25519PrivKey(priv), priv
+}
+
+func (k *extendedKey) wipe() {
+	clear(k.key)
+	clear(k.chainCode)
+}
+
+// MasterNode is an account node at m/44'/2046'/index'. Its binary form is the
+// base64-decoded "account key" that Anytype stores for an account.
+type MasterNode struct {
+	node *extendedKey
+}
+
+// UnmarshalMasterNode restores a node from the 64-byte key || chain_code blob
+// written by MarshalBinary. The format has no version prefix, so anything other
+// than exactly 64 bytes is rejected rather than silently truncated.
+func UnmarshalMasterNode(data []byte) (*MasterNode, error) {
+	if len(data) != masterNodeLen {
+		return nil, fmt.Errorf("%w: master node must be %d bytes (key || chain code), got %d", ErrEncoding, masterNodeLen, len(data))
+	}
+	return &MasterNode{node: splitExtendedKey(append([]byte(nil), data...))}, nil
+}
+
+// MarshalBinary returns the 64-byte key || chain_code blob.
+func (n *MasterNode) MarshalBinary() ([]byte, error) {
+	b := make([]byte, 0, masterNodeLen)
+	b = append(b, n.node.key...)
+	return append(b, n.node.chainCode...), nil
+}
+
+// Identity derives the identity key at m/44'/2046'/index'/0'. The caller owns
+// the returned key: its bytes are not tracked by the node, so Wipe does not
+// clear them. Functions in this package that only need the key briefly use the
+// unexported identity, which hands back the bytes to clear.
+func (n *MasterNode) Identity() (crypto.PrivKey, error) {
+	identity, _ := n.identity()
+	return identity, nil
+}
+
+// AccountID returns the Anytype account id of the node's identity key.
+func (n *MasterNode) AccountID() (string, error) {
+	identity, priv := n.identity()
+	defer clear(priv)
+	return identity.GetPublic().Account(), nil
+}
+
+// Wipe overwrites the node's key and chain code with zeros. The node must not
+// be used afterwards.
+func (n *MasterNode) Wipe() {
+	n.node.wipe()
+}
+
+// identity derives the identity key and returns its backing bytes.
+func (n *MasterNode) identity() (crypto.PrivKey, []byte) {
+	child := n.node.child(0)
+	defer child.wipe()
+	return child.privKey()
+}
@@ -0,0 +1,128 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/hex"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/anyproto/go-slip10"
+)
+
+func TestSlip10Vector1(t *testing.T) {
+	// SLIP-10 test vector 1 for ed25519, chain m/0'/1'/2'.
+	seed, _ := hex.DecodeString("000102030405060708090a0b0c0d0e0f")
+	k := slip10Derive(seed, []uint32{0, 1, 2})
+	if got := hex.EncodeToString(k.key); got != "92a5b23c0b8a99e37d07df3fb9966917f5d06e02ddbd909c7e184371463e9fc9" {
+		t.Fatalf("key = %s", got)
+	}
+	if got := hex.EncodeToString(k.chainCode); got != "2e69929e00b5ab250f49c3fb1c12f252de4fed2c1db88387094a0f8c4c9ccd6c" {
+		t.Fatalf("chain code = %s", got)
+	}
+}
+
+func TestSlip10MatchesGoSlip10(t *testing.T) {
+	seed, err := crypto.Mnemonic(refMnemonic).Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, tt := range []struct {
+		path  string
+		nodes []uint32
+	}{
+		{"m/44'/2046'/0'", []uint32{44, 2046, 0}},
+		{"m/44'/2046'/7'/0'", []uint32{44, 2046, 7, 0}},
+		{"m/44'/607'/1'", []uint32{44, 607, 1}},
+	} {
+		want, err := slip10.DeriveForPath(tt.path, seed)
+		if err != nil {
+			t.Fatal(err)
+		}
+		wantBytes, err := want.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := (&MasterNode{node: slip10Derive(seed, tt.nodes)}).MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(got, wantBytes) {
+			t.Fatalf("%s: node differs from go-slip10", tt.path)
+		}
+	}
+}
+
+func TestMasterNodeRoundTrip(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := node.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := UnmarshalMasterNode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := restored.AccountID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != refAccountID {
+		t.Fatalf("restored account id = %s, want %s", got, refAccountID)
+	}
+}
+
+func TestUnmarshalMasterNodeRejectsCorrupt(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := node.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		data []byte
+	}{
+		{"empty", nil},
+		{"truncated", data[:len(data)-1]},
+		{"trailing bytes", append(append([]byte(nil), data...), 0x00)},
+		{"key only", data[:32]},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := UnmarshalMasterNode(tt.data); err == nil {
+				t.Fatalf("UnmarshalMasterNode(%d bytes) succeeded, want error", len(tt.data))
+			}
+		})
+	}
+}
+
+func TestMasterNodeWipe(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := node.MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored, err := UnmarshalMasterNode(data)
+	if err != nil {
+		t.Fatal(err)
+	}
+	restored.Wipe()
+	if got, _ := restored.MarshalBinary(); !bytes.Equal(got, make([]byte, masterNodeLen)) {
+		t.Fatalf("wiped node = %x, want zeros", got)
+	}
+	// The wiped node must not alias the blob it was restored from.
+	if bytes.Equal(data, make([]byte, masterNodeLen)) {
+		t.Fatal("Wipe cleared the caller's buffer")
+	}
+}
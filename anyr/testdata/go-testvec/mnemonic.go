@@ -0,0 +1,176 @@
+// BIP39 mnemonic validation.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	// ErrMnemonicLength is returned when a phrase does not have 12, 15, 18, 21 or 24 words.
+	ErrMnemonicLength = fmt.Errorf("%w: must have 12, 15, 18, 21 or 24 words", ErrInvalidMnemonic)
+	// ErrMnemonicWord is returned when a phrase contains a word that is not in the
+	// wordlist. The returned error is a *MnemonicWordError.
+	ErrMnemonicWord = fmt.Errorf("%w: word not in BIP39 wordlist", ErrInvalidMnemonic)
+	// ErrMnemonicChecksum is returned when the checksum bits of a phrase do not match its entropy.
+	ErrMnemonicChecksum = fmt.Errorf("%w: checksum mismatch", ErrInvalidMnemonic)
+	// ErrMnemonicStrength is returned when a requested entropy size is not supported.
+	ErrMnemonicStrength = errors.New("mnemonic strength must be 128, 160, 192, 224 or 256 bits")
+)
+
+// MnemonicWordError reports the position of a word that is not in the wordlist.
+// The word itself is deliberately omitted so the error is safe to log.
+type MnemonicWordError struct {
+	// Index is the zero-based position of the offending word.
+	Index int
+}
+
+func (e *MnemonicWordError) Error() string {
+	return fmt.Sprintf("%s: word %d", ErrMnemonicWord, e.Index+1)
+}
+
+func (e *MnemonicWordError) Unwrap() error {
+	return ErrMnemonicWord
+}
+
+// ValidateMnemonic checks that phrase is a well-formed BIP39 English mnemonic:
+// the word count, that every word is in the wordlist, and the checksum bits.
+// Words must be separated by single spaces, which is the form that is fed to the
+// seed derivation.
+func ValidateMnemonic(phrase string) error {
+	entropy, err := English.entropy(strings.Split(phrase, " "))
+	clear(entropy)
+	return err
+}
+
+// NormalizeMnemonic cleans up a phrase pasted by a user and validates it.
+// Leading and trailing whitespace and periods are removed, runs of whitespace
+// (including non-breaking spaces and newlines) collapse to a single space, and
+// words are lowercased. The result is checked with ValidateMnemonic and fails
+// with the same errors.
+func NormalizeMnemonic(raw string) (crypto.Mnemonic, error) {
+	phrase := canonicalMnemonic(raw)
+	if err := ValidateMnemonic(phrase); err != nil {
+		return "", err
+	}
+	return crypto.Mnemonic(phrase), nil
+}
+
+// MnemonicEqual reports whether a and b are the same phrase after the clean-up
+// done by NormalizeMnemonic. The canonical forms are hashed and the digests
+// compared in constant time, so the result does not reveal how many leading
+// words matched; only the time to hash each phrase depends on its length.
+// Neither phrase needs to be valid.
+func MnemonicEqual(a, b crypto.Mnemonic) bool {
+	ha := sha256.Sum256([]byte(canonicalMnemonic(string(a))))
+	hb := sha256.Sum256([]byte(canonicalMnemonic(string(b))))
+	return subtle.ConstantTimeCompare(ha[:], hb[:]) == 1
+}
+
+// canonicalMnemonic trims whitespace and periods, collapses whitespace runs to
+// single spaces and lowercases the words.
+func canonicalMnemonic(raw string) string {
+	trimmed := strings.TrimFunc(raw, func(r rune) bool {
+		return r == '.' || unicode.IsSpace(r)
+	})
+	return strings.ToLower(strings.Join(strings.Fields(trimmed), " "))
+}
+
+// entropy decodes words into the entropy bytes they encode, verifying the
+// trailing checksum bits. The caller must clear the result; on error the
+// partly decoded buffer is cleared before returning.
+func (wl *Wordlist) entropy(words []string) ([]byte, error) {
+	switch len(words) {
+	case 12, 15, 18, 21, 24:
+	default:
+		return nil, ErrMnemonicLength
+	}
+
+	// Each word carries 11 bits: ENT bits of entropy followed by ENT/32 checksum bits.
+	totalBits := len(words) * 11
+	checksumBits := totalBits / 33
+	entropyBits := totalBits - checksumBits
+
+	buf := make([]byte, (totalBits+7)/8)
+	for i, w := range words {
+		idx, ok := wl.index[w]
+		if !ok {
+			clear(buf)
+			return nil, &MnemonicWordError{Index: i}
+		}
+		for b := range 11 {
+			if idx&(1<<(10-b)) != 0 {
+				pos := i*11 + b
+				buf[pos/8] |= 0x80 >> (pos % 8)
+			}
+		}
+	}
+
+	entropy := buf[:entropyBits/8]
+	want := buf[entropyBits/8] >> (8 - checksumBits)
+	sum := sha256.Sum256(entropy)
+	if sum[0]>>(8-checksumBits) != want {
+		clear(buf)
+		return nil, ErrMnemonicChecksum
+	}
+	return entropy, nil
+}
+
+// NewMnemonic generates a new phrase from strength bits of crypto/rand entropy.
+// Strength must be 128, 160, 192, 224 or 256, giving 12 to 24 words.
+func NewMnemonic(strength int) (crypto.Mnemonic, error) {
+	return NewMnemonicFromReader(rand.Reader, strength)
+}
+
+// NewMnemonicFromReader is like NewMnemonic but reads entropy from r, which
+// lets tests supply deterministic input. The entropy buffer is zeroed before
+// returning.
+func NewMnemonicFromReader(r io.Reader, strength int) (crypto.Mnemonic, error) {
+	if strength%32 != 0 || strength < 128 || strength > 256 {
+		return "", fmt.Errorf("%w: got %d", ErrMnemonicStrength, strength)
+	}
+	entropy := make([]byte, strength/8)
+	defer clear(entropy)
+	if _, err := io.ReadFull(r, entropy); err != nil {
+		return "", fmt.Errorf("read entropy: %w", err)
+	}
+	return entropyMnemonic(entropy), nil
+}
+
+// entropyMnemonic appends the checksum bits to entropy and maps each 11-bit
+// group to a word.
+func entropyMnemonic(entropy []byte) crypto.Mnemonic {
+	checksumBits := len(entropy) * 8 / 32
+	buf := make([]byte, len(entropy)+1)
+	defer clear(buf)
+	copy(buf, entropy)
+	sum := sha256.Sum256(entropy)
+	buf[len(entropy)] = sum[0] & (0xff << (8 - checksumBits))
+	clear(sum[:])
+
+	words := make([]string, (len(entropy)*8+checksumBits)/11)
+	for i := range words {
+		idx := 0
+		for b := range 11 {
+			pos := i*11 + b
+			idx <<= 1
+			if buf[pos/8]&(0x80>>(pos%8)) != 0 {
+				idx |= 1
+			}
+		}
+		words[i] = English.words[idx]
+	}
+	return crypto.Mnemonic(strings.Join(words, " "))
+}
@@ -0,0 +1,218 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+const refMnemonic = "tag volcano eight thank tide danger coast health above argue embrace heavy"
+
+func TestValidateMnemonic(t *testing.T) {
+	tests := []struct {
+		name   string
+		phrase string
+		want   error
+	}{
+		{"reference", refMnemonic, nil},
+		{"24 words", "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art", nil},
+		{"empty", "", ErrMnemonicLength},
+		{"11 words", "tag volcano eight thank tide danger coast health above argue embrace", ErrMnemonicLength},
+		{"13 words", refMnemonic + " heavy", ErrMnemonicLength},
+		{"unknown word", "tag volcano eight thank tide danger coast health above argue embrace heavyy", ErrMnemonicWord},
+		{"bad checksum", "tag volcano eight thank tide danger coast health above argue embrace embrace", ErrMnemonicChecksum},
+		{"double space", "tag  volcano eight thank tide danger coast health above argue embrace", ErrMnemonicWord},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateMnemonic(tt.phrase)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("ValidateMnemonic() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestValidateMnemonicWordIndex(t *testing.T) {
+	err := ValidateMnemonic("tag volcano eight thank tide danger coast notaword above argue embrace heavy")
+	var wordErr *MnemonicWordError
+	if !errors.As(err, &wordErr) {
+		t.Fatalf("expected *MnemonicWordError, got %v", err)
+	}
+	if wordErr.Index != 7 {
+		t.Fatalf("Index = %d, want 7", wordErr.Index)
+	}
+}
+
+func TestDeriveKeysRejectsInvalidMnemonic(t *testing.T) {
+	bad := crypto.Mnemonic("tag volcano eight thank tide danger coast health above argue embrace embrace")
+	if _, err := DeriveKeys(bad, 0); !errors.Is(err, ErrMnemonicChecksum) {
+		t.Fatalf("DeriveKeys() = %v, want %v", err, ErrMnemonicChecksum)
+	}
+	if _, err := DeriveMasterNode(bad, 0); !errors.Is(err, ErrMnemonicChecksum) {
+		t.Fatalf("DeriveMasterNode() = %v, want %v", err, ErrMnemonicChecksum)
+	}
+}
+
+func TestDeriveKeysReference(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const want = "A9ZJ9CkjFnMLw8Lsgt8gnVTBqhrx1fRPbdCSucdpXxVi78WW"
+	if got := res.Identity().GetPublic().Account(); got != want {
+		t.Fatalf("account id = %s, want %s", got, want)
+	}
+}
+
+func TestNewMnemonicFromReader(t *testing.T) {
+	// Vectors from the BIP39 reference test suite.
+	tests := []struct {
+		entropy []byte
+		want    string
+	}{
+		{bytes.Repeat([]byte{0x00}, 16), "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"},
+		{bytes.Repeat([]byte{0x7f}, 16), "legal winner thank year wave sausage worth useful legal winner thank yellow"},
+		{bytes.Repeat([]byte{0x80}, 24), "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always"},
+		{bytes.Repeat([]byte{0xff}, 32), "zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo zoo vote"},
+	}
+	for _, tt := range tests {
+		got, err := NewMnemonicFromReader(bytes.NewReader(tt.entropy), len(tt.entropy)*8)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if string(got) != tt.want {
+			t.Fatalf("entropy %x: got %q, want %q", tt.entropy, got, tt.want)
+		}
+	}
+}
+
+func TestNewMnemonicStrength(t *testing.T) {
+	for _, strength := range []int{0, 96, 127, 136, 288} {
+		if _, err := NewMnemonic(strength); !errors.Is(err, ErrMnemonicStrength) {
+			t.Fatalf("NewMnemonic(%d) = %v, want %v", strength, err, ErrMnemonicStrength)
+		}
+	}
+	if _, err := NewMnemonicFromReader(bytes.NewReader(make([]byte, 8)), 128); err == nil {
+		t.Fatal("expected error for short entropy reader")
+	}
+	for _, strength := range []int{128, 160, 192, 224, 256} {
+		m, err := NewMnemonic(strength)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if n := len(strings.Fields(string(m))); n != strength/32*3 {
+			t.Fatalf("strength %d gave %d words", strength, n)
+		}
+		if err := ValidateMnemonic(string(m)); err != nil {
+			t.Fatalf("generated phrase does not validate: %v", err)
+		}
+	}
+}
+
+func TestNormalizeMnemonic(t *testing.T) {
+	const want24 = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon art"
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{"canonical", refMnemonic, refMnemonic},
+		{"padded", "  \t" + refMnemonic + "\n", refMnemonic},
+		{"internal whitespace", "tag  volcano\teight\nthank tide danger coast health above argue embrace heavy", refMnemonic},
+		{"uppercase", strings.ToUpper(refMnemonic), refMnemonic},
+		{"trailing period", refMnemonic + ".", refMnemonic},
+		{"non-breaking spaces", strings.ReplaceAll(refMnemonic, " ", "\u00a0"), refMnemonic},
+		{"24 words", " " + strings.ReplaceAll(want24, " ", "   ") + ". ", want24},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NormalizeMnemonic(tt.raw)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(got) != tt.want {
+				t.Fatalf("NormalizeMnemonic() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNormalizeMnemonicErrors(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want error
+	}{
+		{"", ErrMnemonicLength},
+		{"tag volcano eight", ErrMnemonicLength},
+		{"TAG volcano eight thank tide danger coast health above argue embrace heavyy", ErrMnemonicWord},
+		{"tag volcano eight thank tide danger coast health above argue embrace embrace.", ErrMnemonicChecksum},
+	}
+	for _, tt := range tests {
+		if _, err := NormalizeMnemonic(tt.raw); !errors.Is(err, tt.want) {
+			t.Fatalf("NormalizeMnemonic(%q) = %v, want %v", tt.raw, err, tt.want)
+		}
+	}
+}
+
+func TestMnemonicEqual(t *testing.T) {
+	tests := []struct {
+		name string
+		a, b string
+		want bool
+	}{
+		{"identical", refMnemonic, refMnemonic, true},
+		{"whitespace and case", refMnemonic, "  TAG volcano\teight thank tide danger coast health above argue embrace  heavy.\n", true},
+		{"last word differs", refMnemonic, "tag volcano eight thank tide danger coast health above argue embrace embrace", false},
+		{"prefix", refMnemonic, "tag volcano eight", false},
+		{"empty", "", refMnemonic, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := MnemonicEqual(crypto.Mnemonic(tt.a), crypto.Mnemonic(tt.b)); got != tt.want {
+				t.Fatalf("MnemonicEqual() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+// FuzzNormalizeMnemonic checks that NormalizeMnemonic never panics and either
+// returns a phrase that is valid and already normalized, or an error matching
+// ErrInvalidMnemonic. The seeds cover the empty string, a single word, a valid
+// phrase, a phrase with embedded NUL bytes, a bad checksum and non-ASCII
+// whitespace and case folding.
+func FuzzNormalizeMnemonic(f *testing.F) {
+	for _, seed := range []string{
+		"",
+		"abandon",
+		refMnemonic,
+		strings.ReplaceAll(refMnemonic, " ", "\x00"),
+		"tag volcano eight thank tide danger coast\x00 health above argue embrace heavy",
+		"tag volcano eight thank tide danger coast health above argue embrace embrace",
+		"　TAG volcano eight thank tide danger coast health above argue embrace heavy.",
+		"\u0130 \u00df \u01c5 \ufeff \u200b",
+	} {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, raw string) {
+		m, err := NormalizeMnemonic(raw)
+		if err != nil {
+			if !errors.Is(err, ErrInvalidMnemonic) {
+				t.Fatalf("NormalizeMnemonic(%q) = %v, want an ErrInvalidMnemonic error", raw, err)
+			}
+			return
+		}
+		if err := ValidateMnemonic(string(m)); err != nil {
+			t.Fatalf("normalized phrase %q does not validate: %v", m, err)
+		}
+		if again, err := NormalizeMnemonic(string(m)); err != nil || again != m {
+			t.Fatalf("NormalizeMnemonic is not idempotent for %q: %q, %v", m, again, err)
+		}
+	})
+}
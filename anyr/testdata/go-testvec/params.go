@@ -0,0 +1,96 @@
+// Non-standard PBKDF2 iteration counts for test fixtures.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// ErrInsecureIterations is returned when DeriveKeysParams asks for a
+// non-standard PBKDF2 iteration count without AllowInsecureIterations, or for
+// one below MinIterations with it.
+var ErrInsecureIterations = errors.New("insecure PBKDF2 iteration count")
+
+// DeriveKeysParams changes the PBKDF2 iteration count used by
+// DeriveKeysWithParams. It exists so test harnesses can derive many fixture
+// accounts quickly; the standard API always uses the BIP39 count of 2048.
+//
+// Keys and vectors derived with any count other than 2048 are not
+// interoperable with Anytype: the same mnemonic gives a different account.
+// Never use them for a real account.
+type DeriveKeysParams struct {
+	// Iterations is the PBKDF2 iteration count. Zero means 2048.
+	Iterations int
+	// MinIterations is the lowest count accepted with AllowInsecureIterations,
+	// so a harness can opt in to fast fixtures without going below a count it
+	// trusts. Zero means no floor. It has no effect without the flag, where the
+	// count is always 2048.
+	MinIterations int
+	// AllowInsecureIterations permits any count other than 2048, lower and
+	// higher ones alike, since every such count derives an account Anytype
+	// cannot open. Test mode only.
+	AllowInsecureIterations bool
+}
+
+// iterations returns the checked iteration count.
+func (p DeriveKeysParams) iterations() (int, error) {
+	n := p.Iterations
+	if n == 0 {
+		n = pbkdf2Iterations
+	}
+	if n < 1 {
+		return 0, fmt.Errorf("%w: %d", ErrInsecureIterations, n)
+	}
+	if p.AllowInsecureIterations {
+		if n < p.MinIterations {
+			return 0, fmt.Errorf("%w: %d is below the minimum of %d", ErrInsecureIterations, n, p.MinIterations)
+		}
+		return n, nil
+	}
+	if n != pbkdf2Iterations {
+		return 0, fmt.Errorf("%w: %d is not the BIP39 count of %d", ErrInsecureIterations, n, pbkdf2Iterations)
+	}
+	return n, nil
+}
+
+// DeriveKeysWithParams is like DeriveKeysContext with a non-standard PBKDF2
+// iteration count. With zero-valued params it is identical to DeriveKeysContext.
+func DeriveKeysWithParams(ctx context.Context, m crypto.Mnemonic, index int, params DeriveKeysParams) (*KeyResult, error) {
+	start := time.Now()
+	res, err := deriveKeysWithParams(ctx, m, index, params)
+	if err != nil {
+		logDerivation("DeriveKeysWithParams", start, err, "index", index, "iterations", params.Iterations)
+		return nil, err
+	}
+	logDerivation("DeriveKeysWithParams", start, nil, "index", index, "iterations", params.Iterations, "account_id", res.AccountID)
+	return res, nil
+}
+
+func deriveKeysWithParams(ctx context.Context, m crypto.Mnemonic, index int, params DeriveKeysParams) (*KeyResult, error) {
+	iterations, err := params.iterations()
+	if err != nil {
+		return nil, err
+	}
+	if err := checkIndex(index); err != nil {
+		return nil, err
+	}
+	if err := ValidateMnemonic(string(m)); err != nil {
+		return nil, err
+	}
+	seed, err := deriveSeed(ctx, []byte(m), nil, iterations)
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	prefixes := seedAccountPrefixes(seed)
+	defer prefixes.wipe()
+	return prefixes.keys(uint32(index)), nil
+}
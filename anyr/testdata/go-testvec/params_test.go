@@ -0,0 +1,61 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestDeriveKeysWithParamsDefault(t *testing.T) {
+	// Without AllowInsecureIterations, MinIterations does not affect 2048.
+	for _, params := range []DeriveKeysParams{{}, {Iterations: 2048}, {MinIterations: 4096}} {
+		res, err := DeriveKeysWithParams(context.Background(), crypto.Mnemonic(refMnemonic), 0, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if res.AccountID != refAccountID {
+			t.Fatalf("%+v: account id = %s, want %s", params, res.AccountID, refAccountID)
+		}
+	}
+}
+
+func TestDeriveKeysWithParamsFloor(t *testing.T) {
+	tests := []struct {
+		name   string
+		params DeriveKeysParams
+		ok     bool
+	}{
+		{"below standard", DeriveKeysParams{Iterations: 16}, false},
+		{"negative", DeriveKeysParams{Iterations: -1, AllowInsecureIterations: true}, false},
+		{"floor needs the flag", DeriveKeysParams{Iterations: 16, MinIterations: 8}, false},
+		{"floor ignored without the flag", DeriveKeysParams{Iterations: 4096, MinIterations: 4096}, false},
+		{"below floor allowed", DeriveKeysParams{Iterations: 16, MinIterations: 32, AllowInsecureIterations: true}, false},
+		{"at floor allowed", DeriveKeysParams{Iterations: 32, MinIterations: 32, AllowInsecureIterations: true}, true},
+		{"above standard", DeriveKeysParams{Iterations: 4096}, false},
+		{"above standard allowed", DeriveKeysParams{Iterations: 4096, AllowInsecureIterations: true}, true},
+		{"insecure allowed", DeriveKeysParams{Iterations: 16, AllowInsecureIterations: true}, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			res, err := DeriveKeysWithParams(context.Background(), crypto.Mnemonic(refMnemonic), 0, tt.params)
+			if !tt.ok {
+				if !errors.Is(err, ErrInsecureIterations) {
+					t.Fatalf("DeriveKeysWithParams() = %v, want %v", err, ErrInsecureIterations)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			// A non-standard count is a different account.
+			if res.AccountID == refAccountID {
+				t.Fatal("non-standard iteration count gave the standard account id")
+			}
+		})
+	}
+}
@@ -0,0 +1,30 @@
+// libp2p peer ids of account keys.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"fmt"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// PeerID returns the libp2p peer id of the identity key, the key the account
+// id encodes: base58 of the identity multihash of the protobuf-marshaled
+// public key, as any-sync's PubKey.PeerId computes it. It is the peer id used
+// in the identity's handshake credentials. Anytype clients connect to nodes
+// with a separate per-device peer key, so this is not a device's peer id.
+// Unlike PubKey.PeerId, encoding failures are returned rather than yielding "".
+func (r *KeyResult) PeerID() (string, error) {
+	pk, err := r.Identity().GetPublic().LibP2P()
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	id, err := peer.IDFromPublicKey(pk)
+	if err != nil {
+		return "", fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	return id.String(), nil
+}
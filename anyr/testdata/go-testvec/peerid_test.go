@@ -0,0 +1,45 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+// refPeerID is the libp2p peer id of the identity key of refMnemonic account
+// 0, checked against an independent base58 encoding of the identity
+// multihash of the account id's public key.
+const refPeerID = "12D3KooWMUz8P5eWo5kEVu9yESF5PPVrVPo2K3S33pA8c4qhTTnf"
+
+func TestPeerID(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := res.PeerID()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != refPeerID {
+		t.Fatalf("PeerID() = %s, want %s", got, refPeerID)
+	}
+	if want := res.Identity().GetPublic().PeerId(); got != want {
+		t.Fatalf("PeerID() = %s, any-sync PeerId() = %s", got, want)
+	}
+
+	// Build the id by hand: identity multihash (0x00, length) of the libp2p
+	// protobuf PublicKey{Type: Ed25519, Data: raw}.
+	raw, err := res.Identity().GetPublic().Raw()
+	if err != nil {
+		t.Fatal(err)
+	}
+	marshaled := append([]byte{0x08, 0x01, 0x12, byte(len(raw))}, raw...)
+	manual := base58.Encode(append([]byte{0x00, byte(len(marshaled))}, marshaled...))
+	if got != manual {
+		t.Fatalf("PeerID() = %s, hand-built id = %s", got, manual)
+	}
+}
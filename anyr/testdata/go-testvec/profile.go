@@ -0,0 +1,33 @@
+// Public account profile.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import "encoding/base64"
+
+// PublicProfile is the public part of a KeyResult, safe to return from an API
+// or log. It holds only strings and numbers, so it cannot alias private key
+// storage, and its fields always marshal to JSON in declaration order.
+type PublicProfile struct {
+	// AccountID is the account id of the identity key.
+	AccountID string `json:"account_id"`
+	// DIDKey is the did:key identifier of the identity key.
+	DIDKey string `json:"did_key"`
+	// SigningKey is the standard base64 of the raw MasterKey public key.
+	SigningKey string `json:"signing_key"`
+	// Index is the account index.
+	Index uint32 `json:"index"`
+}
+
+// PublicProfile returns the public identifiers of r. Raw Ed25519 public keys
+// cannot fail to encode, so a key of another type leaves its field empty.
+func (r *KeyResult) PublicProfile() PublicProfile {
+	p := PublicProfile{AccountID: r.AccountID, Index: r.Index}
+	p.DIDKey, _ = PubKeyToDIDKey(r.Identity().GetPublic())
+	if raw, err := r.MasterKey().GetPublic().Raw(); err == nil {
+		p.SigningKey = base64.StdEncoding.EncodeToString(raw)
+	}
+	return p
+}
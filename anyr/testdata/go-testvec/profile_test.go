@@ -0,0 +1,55 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestPublicProfile(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := res.PublicProfile()
+	want := PublicProfile{
+		AccountID:  refAccountID,
+		DIDKey:     refDIDKey,
+		SigningKey: "MpqmS05MJZPMPYMYmw0sX1oudSK/A6zncoa/rixLqDc=",
+		Index:      0,
+	}
+	if p != want {
+		t.Fatalf("PublicProfile() = %+v, want %+v", p, want)
+	}
+
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantJSON := `{"account_id":"` + refAccountID + `","did_key":"` + refDIDKey + `","signing_key":"MpqmS05MJZPMPYMYmw0sX1oudSK/A6zncoa/rixLqDc=","index":0}`
+	if string(data) != wantJSON {
+		t.Fatalf("json = %s, want %s", data, wantJSON)
+	}
+	var back PublicProfile
+	if err := json.Unmarshal(data, &back); err != nil {
+		t.Fatal(err)
+	}
+	if back != p {
+		t.Fatalf("round trip = %+v, want %+v", back, p)
+	}
+
+	// Only value types: nothing in a profile can share memory with a key.
+	typ := reflect.TypeFor[PublicProfile]()
+	for i := range typ.NumField() {
+		switch f := typ.Field(i); f.Type.Kind() {
+		case reflect.String, reflect.Uint32:
+		default:
+			t.Fatalf("field %s has kind %s, want string or uint32", f.Name, f.Type.Kind())
+		}
+	}
+}
@@ -0,0 +1,117 @@
+// Per-client rate limiting of derivation requests.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+var (
+	// ErrRateLimited is returned by RateLimitedDeriver when a client has used
+	// up its derivation budget.
+	ErrRateLimited = errors.New("derivation rate limit exceeded")
+	// ErrInvalidRate is returned by NewRateLimitedDeriver for a NaN or
+	// infinite rate, either of which would disable limiting.
+	ErrInvalidRate = errors.New("rate limit must be a finite number")
+)
+
+// tokenBucket is one client's budget: tokens available as of last.
+type tokenBucket struct {
+	tokens float64
+	last   time.Time
+}
+
+// RateLimitedDeriver derives keys behind a token bucket per client key, such
+// as a client IP, so a public endpoint cannot be used to try candidate phrases
+// quickly. Each client may make burst derivations at once, refilled at rate
+// per second. Every attempt costs a token, whether or not the phrase is valid.
+// It is safe for concurrent use.
+type RateLimitedDeriver struct {
+	rate  float64
+	burst float64
+	now   func() time.Time
+
+	mu        sync.Mutex
+	buckets   map[string]*tokenBucket
+	sweepSize int // bucket count that triggers the next sweep of idle clients
+}
+
+// NewRateLimitedDeriver returns a deriver allowing each client burst
+// derivations at once and rate per second after that. A burst below 1 is
+// treated as 1 and a rate below 0 as 0, which never refills. A NaN or
+// infinite rate fails with ErrInvalidRate.
+func NewRateLimitedDeriver(rate float64, burst int) (*RateLimitedDeriver, error) {
+	if math.IsNaN(rate) || math.IsInf(rate, 0) {
+		return nil, fmt.Errorf("%w: got %v", ErrInvalidRate, rate)
+	}
+	return &RateLimitedDeriver{
+		rate:      max(rate, 0),
+		burst:     float64(max(burst, 1)),
+		now:       time.Now,
+		buckets:   make(map[string]*tokenBucket),
+		sweepSize: 1024,
+	}, nil
+}
+
+// DeriveKeys takes a token from client's bucket and derives the keys like
+// DeriveKeysContext. With no token left it fails with ErrRateLimited without
+// deriving.
+func (d *RateLimitedDeriver) DeriveKeys(ctx context.Context, client string, m crypto.Mnemonic, index int) (*KeyResult, error) {
+	if !d.allow(client) {
+		return nil, fmt.Errorf("%w: client %q", ErrRateLimited, client)
+	}
+	return DeriveKeysContext(ctx, m, index)
+}
+
+// allow reports whether client has a token, and takes it if so.
+func (d *RateLimitedDeriver) allow(client string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	now := d.now()
+	b, ok := d.buckets[client]
+	if !ok {
+		d.sweep(now)
+		b = &tokenBucket{tokens: d.burst, last: now}
+		d.buckets[client] = b
+	}
+	d.refill(b, now)
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// refill adds the tokens earned since b.last, up to burst.
+func (d *RateLimitedDeriver) refill(b *tokenBucket, now time.Time) {
+	if elapsed := now.Sub(b.last); elapsed > 0 {
+		b.tokens = min(d.burst, b.tokens+elapsed.Seconds()*d.rate)
+		b.last = now
+	}
+}
+
+// sweep drops buckets that have refilled to burst, which behave the same as a
+// new bucket, once the map has grown to sweepSize. The next sweep waits until
+// the map doubles, so sweeping is amortized over insertions. d.mu must be held.
+func (d *RateLimitedDeriver) sweep(now time.Time) {
+	if len(d.buckets) < d.sweepSize {
+		return
+	}
+	for client, b := range d.buckets {
+		d.refill(b, now)
+		if b.tokens >= d.burst {
+			delete(d.buckets, client)
+		}
+	}
+	d.sweepSize = max(d.sweepSize, 2*len(d.buckets))
+}
@@ -0,0 +1,133 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// fakeClock is a settable time source for RateLimitedDeriver.
+type fakeClock struct {
+	mu sync.Mutex
+	t  time.Time
+}
+
+func (c *fakeClock) now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.t
+}
+
+func (c *fakeClock) advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.t = c.t.Add(d)
+}
+
+func TestRateLimitedDeriver(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	d, err := NewRateLimitedDeriver(2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.now = clock.now
+	m := crypto.Mnemonic(refMnemonic)
+	ctx := context.Background()
+
+	for i := range 3 {
+		res, err := d.DeriveKeys(ctx, "10.0.0.1", m, 0)
+		if err != nil {
+			t.Fatalf("attempt %d within burst: %v", i, err)
+		}
+		if res.AccountID != refAccountID {
+			t.Fatalf("attempt %d: account id %s", i, res.AccountID)
+		}
+	}
+	// Invalid phrases use up tokens too, so probing with junk is limited.
+	if _, err := d.DeriveKeys(ctx, "10.0.0.1", "junk", 0); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("past burst = %v, want %v", err, ErrRateLimited)
+	}
+	if _, err := d.DeriveKeys(ctx, "10.0.0.1", m, 0); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("past burst = %v, want %v", err, ErrRateLimited)
+	}
+	// Other clients have their own bucket.
+	if _, err := d.DeriveKeys(ctx, "10.0.0.2", m, 0); err != nil {
+		t.Fatalf("second client: %v", err)
+	}
+
+	// At 2 tokens per second, half a second refills one token.
+	clock.advance(500 * time.Millisecond)
+	if _, err := d.DeriveKeys(ctx, "10.0.0.1", m, 0); err != nil {
+		t.Fatalf("after refill: %v", err)
+	}
+	if _, err := d.DeriveKeys(ctx, "10.0.0.1", m, 0); !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("after spending the refill = %v, want %v", err, ErrRateLimited)
+	}
+	// A long pause refills only up to the burst.
+	clock.advance(time.Hour)
+	for range 3 {
+		if !d.allow("10.0.0.1") {
+			t.Fatal("burst not restored after a long pause")
+		}
+	}
+	if d.allow("10.0.0.1") {
+		t.Fatal("refill exceeded the burst")
+	}
+}
+
+func TestRateLimitedDeriverConcurrent(t *testing.T) {
+	d, err := NewRateLimitedDeriver(0, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var allowed atomic.Int32
+	var wg sync.WaitGroup
+	for range 50 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if d.allow("client") {
+				allowed.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+	if got := allowed.Load(); got != 10 {
+		t.Fatalf("%d attempts allowed, want the burst of 10", got)
+	}
+}
+
+func TestRateLimitedDeriverSweep(t *testing.T) {
+	clock := &fakeClock{t: time.Unix(1_700_000_000, 0)}
+	d, err := NewRateLimitedDeriver(1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	d.now = clock.now
+	d.sweepSize = 4
+	for _, c := range []string{"a", "b", "c", "d"} {
+		d.allow(c)
+	}
+	clock.advance(time.Second)
+	d.allow("e")
+	if len(d.buckets) != 1 {
+		t.Fatalf("%d buckets after sweep, want 1", len(d.buckets))
+	}
+}
+
+func TestNewRateLimitedDeriverRejectsNonFiniteRate(t *testing.T) {
+	for _, rate := range []float64{math.NaN(), math.Inf(1), math.Inf(-1)} {
+		if d, err := NewRateLimitedDeriver(rate, 1); !errors.Is(err, ErrInvalidRate) || d != nil {
+			t.Fatalf("NewRateLimitedDeriver(%v) = %v, %v; want %v", rate, d, err, ErrInvalidRate)
+		}
+	}
+}
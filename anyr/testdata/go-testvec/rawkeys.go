@@ -0,0 +1,44 @@
+// Raw Ed25519 key material for use outside any-sync.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/ed25519"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// RawIdentity returns the identity key as a standard Ed25519 seed and public
+// key: ed25519.NewKeyFromSeed(seed) is the same key. The arrays are copies;
+// the caller should clear seed when done with it.
+func (r *KeyResult) RawIdentity() (seed, pub [32]byte, err error) {
+	return rawEd25519(r.Identity())
+}
+
+// RawSigning is like RawIdentity for the current signing key, MasterKey.
+func (r *KeyResult) RawSigning() (seed, pub [32]byte, err error) {
+	return rawEd25519(r.MasterKey())
+}
+
+// rawEd25519 splits an any-sync Ed25519 private key, whose Raw form is the
+// 64-byte seed || pub layout of crypto/ed25519, into its halves.
+func rawEd25519(key crypto.PrivKey) (seed, pub [32]byte, err error) {
+	if _, ok := key.(*crypto.Ed25519PrivKey); !ok {
+		return seed, pub, fmt.Errorf("%w: %T is not an Ed25519 private key", ErrUnsupportedKeyType, key)
+	}
+	raw, err := key.Raw()
+	if err != nil {
+		return seed, pub, err
+	}
+	defer clear(raw)
+	if len(raw) != ed25519.PrivateKeySize {
+		return seed, pub, fmt.Errorf("%w: Ed25519 private key is %d bytes", ErrEncoding, len(raw))
+	}
+	copy(seed[:], raw[:ed25519.SeedSize])
+	copy(pub[:], raw[ed25519.SeedSize:])
+	return seed, pub, nil
+}
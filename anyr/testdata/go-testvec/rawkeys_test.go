@@ -0,0 +1,50 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestRawKeys(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for name, raw := range map[string]func() (seed, pub [32]byte, err error){
+		"RawIdentity": res.RawIdentity,
+		"RawSigning":  res.RawSigning,
+	} {
+		seed, pub, err := raw()
+		if err != nil {
+			t.Fatalf("%s: %v", name, err)
+		}
+		priv := ed25519.NewKeyFromSeed(seed[:])
+		if !bytes.Equal(priv.Public().(ed25519.PublicKey), pub[:]) {
+			t.Fatalf("%s: NewKeyFromSeed(seed) public key differs from pub", name)
+		}
+	}
+	_, pub, _ := res.RawIdentity()
+	if got, _ := res.Identity().GetPublic().Raw(); !bytes.Equal(got, pub[:]) {
+		t.Fatal("RawIdentity pub is not the identity public key")
+	}
+}
+
+// notEd25519 is a PrivKey of some other type.
+type notEd25519 struct{ crypto.PrivKey }
+
+func TestRawKeysRejectOtherKeyTypes(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, err := rawEd25519(notEd25519{res.MasterKey()}); !errors.Is(err, ErrUnsupportedKeyType) {
+		t.Fatalf("rawEd25519() = %v, want %v", err, ErrUnsupportedKeyType)
+	}
+}
@@ -0,0 +1,150 @@
+// secp256k1 keys for the signing helpers.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/sha256"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+	p2pcrypto "github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+)
+
+// secp256k1KeyLen is the size of a raw secp256k1 private key.
+const secp256k1KeyLen = 32
+
+// Secp256k1PrivKey is a secp256k1 private key implementing crypto.PrivKey.
+// any-sync's crypto package only defines Ed25519 keys, so the type lives here.
+//
+// Signatures use the libp2p scheme, so they verify with libp2p peers: ECDSA
+// over SHA-256 of the payload with an RFC 6979 nonce, the S value normalized
+// to the lower half of the curve order, DER encoded. Decrypt and Marshall are
+// not supported.
+type Secp256k1PrivKey struct {
+	key *secp256k1.PrivateKey
+}
+
+// Secp256k1PubKey is a secp256k1 public key implementing crypto.PubKey.
+// Account and Network return "": Anytype account ids are only defined for
+// Ed25519 keys, and the account id and did:key helpers reject this type with
+// ErrUnsupportedKeyType.
+type Secp256k1PubKey struct {
+	key *secp256k1.PublicKey
+}
+
+// NewSecp256k1PrivKey returns the key with the 32-byte big-endian scalar raw,
+// which must be in 1..N-1.
+func NewSecp256k1PrivKey(raw []byte) (*Secp256k1PrivKey, error) {
+	if len(raw) != secp256k1KeyLen {
+		return nil, fmt.Errorf("%w: secp256k1 private key is %d bytes, want %d", ErrEncoding, len(raw), secp256k1KeyLen)
+	}
+	var s secp256k1.ModNScalar
+	if overflow := s.SetByteSlice(raw); overflow || s.IsZero() {
+		return nil, fmt.Errorf("%w: secp256k1 private key out of range", ErrEncoding)
+	}
+	return &Secp256k1PrivKey{key: secp256k1.NewPrivateKey(&s)}, nil
+}
+
+func (k *Secp256k1PrivKey) Equals(other crypto.Key) bool {
+	o, ok := other.(*Secp256k1PrivKey)
+	return ok && k.key.Key.Equals(&o.key.Key)
+}
+
+// Raw returns a copy of the 32-byte scalar.
+func (k *Secp256k1PrivKey) Raw() ([]byte, error) {
+	return k.key.Serialize(), nil
+}
+
+func (k *Secp256k1PrivKey) Decrypt([]byte) ([]byte, error) {
+	return nil, fmt.Errorf("%w: secp256k1 decryption", ErrUnsupportedKeyType)
+}
+
+// Sign signs data as described on Secp256k1PrivKey.
+func (k *Secp256k1PrivKey) Sign(data []byte) ([]byte, error) {
+	hash := sha256.Sum256(data)
+	// ecdsa.Sign and Serialize both already produce low S; normalizing here
+	// keeps the guarantee independent of the library.
+	sig := ecdsa.Sign(k.key, hash[:])
+	return normalizeLowS(sig).Serialize(), nil
+}
+
+func (k *Secp256k1PrivKey) GetPublic() crypto.PubKey {
+	return &Secp256k1PubKey{key: k.key.PubKey()}
+}
+
+func (k *Secp256k1PrivKey) Marshall() ([]byte, error) {
+	return nil, fmt.Errorf("%w: any-sync has no proto encoding for secp256k1 keys", ErrUnsupportedKeyType)
+}
+
+func (k *Secp256k1PrivKey) LibP2P() (p2pcrypto.PrivKey, error) {
+	return (*p2pcrypto.Secp256k1PrivateKey)(k.key), nil
+}
+
+func (k *Secp256k1PubKey) Equals(other crypto.Key) bool {
+	o, ok := other.(*Secp256k1PubKey)
+	return ok && k.key.IsEqual(o.key)
+}
+
+// Raw returns the 33-byte compressed point.
+func (k *Secp256k1PubKey) Raw() ([]byte, error) {
+	return k.key.SerializeCompressed(), nil
+}
+
+func (k *Secp256k1PubKey) Encrypt([]byte) ([]byte, error) {
+	return nil, fmt.Errorf("%w: secp256k1 encryption", ErrUnsupportedKeyType)
+}
+
+// Verify reports whether sig is a DER signature of data by k. Signatures with
+// a high S value are rejected, as the signer always produces the low form.
+func (k *Secp256k1PubKey) Verify(data, sig []byte) (bool, error) {
+	parsed, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		return false, fmt.Errorf("%w: secp256k1 signature: %w", ErrEncoding, err)
+	}
+	if s := parsed.S(); s.IsOverHalfOrder() {
+		return false, nil
+	}
+	hash := sha256.Sum256(data)
+	return parsed.Verify(hash[:], k.key), nil
+}
+
+func (k *Secp256k1PubKey) Marshall() ([]byte, error) {
+	return nil, fmt.Errorf("%w: any-sync has no proto encoding for secp256k1 keys", ErrUnsupportedKeyType)
+}
+
+// Storage returns the 33-byte compressed point.
+func (k *Secp256k1PubKey) Storage() []byte { return k.key.SerializeCompressed() }
+
+func (k *Secp256k1PubKey) Account() string { return "" }
+func (k *Secp256k1PubKey) Network() string { return "" }
+
+// PeerId returns the libp2p peer id of the key.
+func (k *Secp256k1PubKey) PeerId() string {
+	id, err := peer.IDFromPublicKey((*p2pcrypto.Secp256k1PublicKey)(k.key))
+	if err != nil {
+		return ""
+	}
+	return id.String()
+}
+
+func (k *Secp256k1PubKey) LibP2P() (p2pcrypto.PubKey, error) {
+	return (*p2pcrypto.Secp256k1PublicKey)(k.key), nil
+}
+
+// normalizeLowS returns sig with S replaced by N-S when S is in the upper half
+// of the curve order. Both forms verify; accepting only the low one removes
+// the malleability of ECDSA signatures.
+func normalizeLowS(sig *ecdsa.Signature) *ecdsa.Signature {
+	r, s := sig.R(), sig.S()
+	if !s.IsOverHalfOrder() {
+		return sig
+	}
+	s.Negate()
+	return ecdsa.NewSignature(&r, &s)
+}
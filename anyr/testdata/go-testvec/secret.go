@@ -0,0 +1,43 @@
+// Redacting wrapper for private key bytes.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"log/slog"
+)
+
+// Secret holds private key bytes that print, log and marshal as "[REDACTED]"
+// with every fmt verb, encoding/json, encoding.TextMarshaler and log/slog.
+// Reveal is the only way to read the bytes.
+type Secret struct {
+	b []byte
+}
+
+// NewSecret wraps b without copying it; the Secret owns b from then on.
+func NewSecret(b []byte) Secret { return Secret{b: b} }
+
+// Reveal returns the secret bytes. The slice is shared with the Secret, so it
+// is zeroed by Wipe and must not be retained past it.
+func (s Secret) Reveal() []byte { return s.b }
+
+// Wipe overwrites the secret bytes with zeros.
+func (s Secret) Wipe() { clear(s.b) }
+
+func (s Secret) String() string   { return redacted }
+func (s Secret) GoString() string { return redacted }
+
+// Format implements fmt.Formatter so that no verb, including %x and %q,
+// formats the bytes.
+func (s Secret) Format(f fmt.State, _ rune) { io.WriteString(f, redacted) }
+
+// MarshalJSON encodes the secret as the JSON string "[REDACTED]".
+func (s Secret) MarshalJSON() ([]byte, error) { return json.Marshal(redacted) }
+
+func (s Secret) MarshalText() ([]byte, error) { return []byte(redacted), nil }
+func (s Secret) LogValue() slog.Value         { return slog.StringValue(redacted) }
@@ -0,0 +1,109 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log/slog"
+	"reflect"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestSecretRedacts(t *testing.T) {
+	raw := []byte("0123456789abcdef")
+	s := NewSecret(raw)
+	if !bytes.Equal(s.Reveal(), raw) {
+		t.Fatal("Reveal() returned different bytes")
+	}
+
+	var logged bytes.Buffer
+	slog.New(slog.NewTextHandler(&logged, nil)).Info("key", "secret", s)
+	js, err := json.Marshal(struct{ S Secret }{s})
+	if err != nil {
+		t.Fatal(err)
+	}
+	outputs := []string{logged.String(), string(js)}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%q", "%x", "%X", "%d"} {
+		outputs = append(outputs, fmt.Sprintf(verb, s), fmt.Sprintf(verb, &s), fmt.Sprintf(verb, []Secret{s}))
+	}
+	for _, out := range outputs {
+		if strings.Contains(out, "0123") || strings.Contains(out, hex.EncodeToString(raw[:4])) || strings.Contains(out, "48 49") {
+			t.Fatalf("output %q contains the secret", out)
+		}
+		if !strings.Contains(out, redacted) {
+			t.Fatalf("output %q is not redacted", out)
+		}
+	}
+
+	s.Wipe()
+	if !bytes.Equal(raw, make([]byte, len(raw))) {
+		t.Fatal("Wipe did not clear the wrapped bytes")
+	}
+}
+
+func TestKeyResultRedacted(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var leaks []string
+	for _, key := range []crypto.PrivKey{res.MasterKey(), res.OldAccountKey(), res.Identity()} {
+		raw, err := key.Raw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		seed := raw[:32]
+		leaks = append(leaks, hex.EncodeToString(seed), base64.StdEncoding.EncodeToString(seed), strings.Trim(fmt.Sprint(seed[:4]), "[]"))
+	}
+	node, err := res.MasterNode().MarshalBinary()
+	if err != nil {
+		t.Fatal(err)
+	}
+	leaks = append(leaks, base64.StdEncoding.EncodeToString(node), hex.EncodeToString(node[:16]))
+
+	ptrJSON, err := json.Marshal(res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valueJSON, err := json.Marshal(*res)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(ptrJSON), refAccountID) {
+		t.Fatalf("json %s lacks the account id", ptrJSON)
+	}
+	outputs := []string{string(ptrJSON), string(valueJSON)}
+	for _, verb := range []string{"%v", "%+v", "%#v", "%s", "%x"} {
+		outputs = append(outputs, fmt.Sprintf(verb, res), fmt.Sprintf(verb, *res))
+	}
+	for _, out := range outputs {
+		for _, leak := range leaks {
+			if strings.Contains(out, leak) {
+				t.Fatalf("output %q contains key bytes %q", out, leak)
+			}
+		}
+	}
+	if got := fmt.Sprintf("%v", res); !strings.Contains(got, redacted) || !strings.Contains(got, refAccountID) {
+		t.Fatalf("%%v = %q, want the account id and %s", got, redacted)
+	}
+
+	// The master node and private keys are only reachable through the
+	// accessor methods.
+	hidden := []reflect.Type{reflect.TypeFor[crypto.PrivKey](), reflect.TypeFor[[]byte](), reflect.TypeFor[*MasterNode]()}
+	typ := reflect.TypeFor[KeyResult]()
+	for i := range typ.NumField() {
+		f := typ.Field(i)
+		if f.IsExported() && slices.Contains(hidden, f.Type) {
+			t.Errorf("KeyResult.%s is an exported %s", f.Name, f.Type)
+		}
+	}
+}
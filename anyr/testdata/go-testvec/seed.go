@@ -0,0 +1,81 @@
+// BIP39 seed expansion.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha512"
+	"crypto/subtle"
+	"fmt"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"golang.org/x/text/unicode/norm"
+)
+
+const (
+	// pbkdf2Iterations is the BIP39 iteration count.
+	pbkdf2Iterations = 2048
+	// pbkdf2Chunk is how many iterations run between context checks.
+	pbkdf2Chunk = 128
+)
+
+// Seed validates m and returns its standard 64-byte BIP39 seed with the
+// optional BIP39 passphrase, for tools that run their own SLIP-10 or BIP32
+// derivation. Anytype always uses the empty passphrase, for which the result
+// equals m.Seed(); any other passphrase gives a seed unrelated to the Anytype
+// account. The passphrase is NFKD-normalized as BIP39 requires. The caller
+// should clear the returned slice when done.
+func Seed(m crypto.Mnemonic, passphrase string) ([]byte, error) {
+	start := time.Now()
+	seed, err := bip39Seed(m, passphrase)
+	logDerivation("Seed", start, err)
+	return seed, err
+}
+
+func bip39Seed(m crypto.Mnemonic, passphrase string) ([]byte, error) {
+	if err := ValidateMnemonic(string(m)); err != nil {
+		return nil, err
+	}
+	return mnemonicSeed(context.Background(), string(m), norm.NFKD.String(passphrase))
+}
+
+// mnemonicSeed computes the 64-byte BIP39 seed, PBKDF2-HMAC-SHA512 of the
+// phrase with salt "mnemonic"+passphrase, using the KDF set by SetKDF. The
+// phrase must already be validated.
+func mnemonicSeed(ctx context.Context, phrase, passphrase string) ([]byte, error) {
+	return deriveSeed(ctx, []byte(phrase), []byte(passphrase), pbkdf2Iterations)
+}
+
+// pbkdf2SHA512 computes a single-block (64-byte) PBKDF2-HMAC-SHA512 key,
+// checking ctx every pbkdf2Chunk iterations so cancellation is observed
+// while the rounds run, not only when they finish.
+func pbkdf2SHA512(ctx context.Context, password, salt []byte, iterations int) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+	}
+	prf := hmac.New(sha512.New, password)
+	prf.Write(salt)
+	prf.Write([]byte{0, 0, 0, 1})
+	u := prf.Sum(nil)
+	defer clear(u)
+	t := append([]byte(nil), u...)
+
+	for i := 1; i < iterations; i++ {
+		if i%pbkdf2Chunk == 0 {
+			if err := ctx.Err(); err != nil {
+				clear(t)
+				return nil, fmt.Errorf("%w: %w", ErrDerivation, err)
+			}
+		}
+		prf.Reset()
+		prf.Write(u)
+		u = prf.Sum(u[:0])
+		subtle.XORBytes(t, t, u)
+	}
+	return t, nil
+}
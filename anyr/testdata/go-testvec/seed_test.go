@@ -0,0 +1,116 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestMnemonicSeedMatchesAnySync(t *testing.T) {
+	want, err := crypto.Mnemonic(refMnemonic).Seed()
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := mnemonicSeed(context.Background(), refMnemonic, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("seed mismatch:\ngot  %x\nwant %x", got, want)
+	}
+}
+
+// cancelAfterCtx reports cancellation once Err has been called n times.
+type cancelAfterCtx struct {
+	context.Context
+	n     int
+	calls int
+}
+
+func (c *cancelAfterCtx) Err() error {
+	c.calls++
+	if c.calls > c.n {
+		return context.Canceled
+	}
+	return nil
+}
+
+func TestDeriveKeysContextCancelledMidSeed(t *testing.T) {
+	// Allow the entry check and the first chunk through, then cancel.
+	ctx := &cancelAfterCtx{Context: context.Background(), n: 2}
+	_, err := DeriveKeysContext(ctx, crypto.Mnemonic(refMnemonic), 0)
+	if !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeriveKeysContext() = %v, want %v", err, context.Canceled)
+	}
+	if ctx.calls != 3 {
+		t.Fatalf("ctx.Err called %d times, want 3", ctx.calls)
+	}
+}
+
+func TestDeriveKeysContext(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+	res, err := DeriveKeysContext(ctx, crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AccountID != refAccountID {
+		t.Fatalf("account id = %s, want %s", res.AccountID, refAccountID)
+	}
+
+	cancel()
+	if _, err := DeriveKeysContext(ctx, crypto.Mnemonic(refMnemonic), 0); !errors.Is(err, context.Canceled) {
+		t.Fatalf("DeriveKeysContext() after cancel = %v, want %v", err, context.Canceled)
+	}
+	if _, err := DeriveKeysContext(context.Background(), crypto.Mnemonic(refMnemonic), -1); !errors.Is(err, ErrInvalidIndex) {
+		t.Fatalf("DeriveKeysContext(-1) = %v, want %v", err, ErrInvalidIndex)
+	}
+}
+
+func TestSeed(t *testing.T) {
+	// BIP39 reference vector for all-zero 128-bit entropy, from the
+	// trezor/python-mnemonic vectors (passphrase "TREZOR") and the same phrase
+	// with the empty passphrase Anytype uses.
+	const phrase = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	tests := []struct {
+		passphrase string
+		want       string
+	}{
+		{"", "5eb00bbddcf069084889a8ab9155568165f5c453ccb85e70811aaed6f6da5fc19a5ac40b389cd370d086206dec8aa6c43daea6690f20ad3d8d48b2d2ce9e38e4"},
+		{"TREZOR", "c55257c360c07c72029aebc1b53c05ed0362ada38ead3e3e9efa3708e53495531f09a6987599d18264c1e1c92f2cf141630c7a3c4ab7c81b2f001698e7463b04"},
+	}
+	for _, tt := range tests {
+		got, err := Seed(phrase, tt.passphrase)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hex.EncodeToString(got) != tt.want {
+			t.Fatalf("Seed(%q) = %x, want %s", tt.passphrase, got, tt.want)
+		}
+	}
+
+	// Composed and decomposed forms of a passphrase are the same passphrase.
+	composed, err := Seed(phrase, "caf\u00e9")
+	if err != nil {
+		t.Fatal(err)
+	}
+	decomposed, err := Seed(phrase, "cafe\u0301")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(composed, decomposed) {
+		t.Fatal("passphrase is not NFKD-normalized")
+	}
+
+	if _, err := Seed("abandon about", ""); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("Seed(invalid) = %v, want %v", err, ErrInvalidMnemonic)
+	}
+}
@@ -0,0 +1,236 @@
+// Shamir secret sharing of mnemonic entropy.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/mr-tron/base58"
+)
+
+const (
+	// shareVersion is the first byte of an encoded share.
+	shareVersion = 1
+	// shareChecksumLen is the length of a share checksum.
+	shareChecksumLen = 4
+	// shareHeaderLen is version, id, threshold and index.
+	shareHeaderLen = 1 + 4 + 1 + 1
+	// maxShares is the number of distinct non-zero x coordinates in GF(256).
+	maxShares = 255
+)
+
+// ErrInvalidShare is returned when shares are malformed, corrupted, from
+// different splits or too few to combine.
+var ErrInvalidShare = errors.New("invalid mnemonic share")
+
+// Share is one share of a mnemonic split by SplitMnemonic. Shares are not
+// BIP39 phrases and are not compatible with SLIP-39.
+type Share struct {
+	// ID identifies the split; every share of one split has the same ID.
+	ID uint32
+	// Threshold is the number of shares needed to combine.
+	Threshold byte
+	// Index is the share's x coordinate, 1..255.
+	Index byte
+	// Value holds one share byte per byte of mnemonic entropy.
+	Value []byte
+	// Checksum is the first 4 bytes of SHA-256 of the share's other fields.
+	Checksum [shareChecksumLen]byte
+}
+
+// SplitMnemonic splits the entropy of m into shares, any threshold of which
+// recombine to m with CombineShares. Fewer than threshold shares reveal
+// nothing about the phrase. threshold must be at least 2 and at most shares,
+// and shares at most 255.
+func SplitMnemonic(m crypto.Mnemonic, threshold, shares int) ([]Share, error) {
+	return splitMnemonic(rand.Reader, m, threshold, shares)
+}
+
+func splitMnemonic(r io.Reader, m crypto.Mnemonic, threshold, shares int) ([]Share, error) {
+	if threshold < 2 || threshold > shares || shares > maxShares {
+		return nil, fmt.Errorf("%w: threshold %d of %d shares, want 2 <= threshold <= shares <= %d", ErrInvalidShare, threshold, shares, maxShares)
+	}
+	secret, err := English.entropy(strings.Split(string(m), " "))
+	if err != nil {
+		return nil, err
+	}
+	defer clear(secret)
+
+	var id [4]byte
+	if _, err := io.ReadFull(r, id[:]); err != nil {
+		return nil, fmt.Errorf("read randomness: %w", err)
+	}
+	// coeffs[i] holds the threshold-1 random coefficients of byte i's polynomial.
+	coeffs := make([]byte, len(secret)*(threshold-1))
+	defer clear(coeffs)
+	if _, err := io.ReadFull(r, coeffs); err != nil {
+		return nil, fmt.Errorf("read randomness: %w", err)
+	}
+
+	out := make([]Share, shares)
+	for s := range out {
+		x := byte(s + 1)
+		value := make([]byte, len(secret))
+		for i, a0 := range secret {
+			value[i] = gfEval(a0, coeffs[i*(threshold-1):(i+1)*(threshold-1)], x)
+		}
+		out[s] = Share{ID: binary.BigEndian.Uint32(id[:]), Threshold: byte(threshold), Index: x, Value: value}
+		out[s].Checksum = out[s].checksum()
+	}
+	return out, nil
+}
+
+// CombineShares reconstructs a phrase from at least Threshold shares of one
+// split. Every share's checksum is verified, and shares beyond the threshold
+// must agree with the others, so a corrupted or foreign share is reported
+// rather than yielding a different phrase.
+func CombineShares(shares []Share) (crypto.Mnemonic, error) {
+	if len(shares) == 0 {
+		return "", fmt.Errorf("%w: no shares", ErrInvalidShare)
+	}
+	first := shares[0]
+	seen := make(map[byte]bool, len(shares))
+	for _, s := range shares {
+		if s.Checksum != s.checksum() {
+			return "", fmt.Errorf("%w: share %d checksum mismatch", ErrInvalidShare, s.Index)
+		}
+		if s.ID != first.ID || s.Threshold != first.Threshold || len(s.Value) != len(first.Value) {
+			return "", fmt.Errorf("%w: share %d is from a different split", ErrInvalidShare, s.Index)
+		}
+		if s.Index == 0 || seen[s.Index] {
+			return "", fmt.Errorf("%w: duplicate or zero share index %d", ErrInvalidShare, s.Index)
+		}
+		seen[s.Index] = true
+	}
+	if first.Threshold < 2 || len(shares) < int(first.Threshold) {
+		return "", fmt.Errorf("%w: have %d shares, need %d", ErrInvalidShare, len(shares), first.Threshold)
+	}
+	switch len(first.Value) {
+	case 16, 20, 24, 28, 32:
+	default:
+		return "", fmt.Errorf("%w: share value is %d bytes", ErrInvalidShare, len(first.Value))
+	}
+
+	basis, extra := shares[:first.Threshold], shares[first.Threshold:]
+	secret := interpolate(basis, 0)
+	defer clear(secret)
+	for _, s := range extra {
+		got := interpolate(basis, s.Index)
+		ok := bytes.Equal(got, s.Value)
+		clear(got)
+		if !ok {
+			return "", fmt.Errorf("%w: share %d is inconsistent with the others", ErrInvalidShare, s.Index)
+		}
+	}
+	m := entropyMnemonic(secret)
+	if err := ValidateMnemonic(string(m)); err != nil {
+		return "", err
+	}
+	return m, nil
+}
+
+// String encodes the share as base58 of version || id || threshold || index
+// || value || checksum.
+func (s Share) String() string {
+	b := s.header()
+	b = append(b, s.Value...)
+	return base58.Encode(append(b, s.Checksum[:]...))
+}
+
+// ParseShare decodes a share written by Share.String and verifies its checksum.
+func ParseShare(text string) (Share, error) {
+	raw, err := base58.Decode(strings.TrimSpace(text))
+	if err != nil {
+		return Share{}, fmt.Errorf("%w: not valid base58: %w", ErrInvalidShare, err)
+	}
+	if len(raw) < shareHeaderLen+shareChecksumLen || raw[0] != shareVersion {
+		return Share{}, fmt.Errorf("%w: bad length or version", ErrInvalidShare)
+	}
+	s := Share{
+		ID:        binary.BigEndian.Uint32(raw[1:5]),
+		Threshold: raw[5],
+		Index:     raw[6],
+		Value:     append([]byte(nil), raw[shareHeaderLen:len(raw)-shareChecksumLen]...),
+	}
+	copy(s.Checksum[:], raw[len(raw)-shareChecksumLen:])
+	if s.Checksum != s.checksum() {
+		return Share{}, fmt.Errorf("%w: checksum mismatch", ErrInvalidShare)
+	}
+	return s, nil
+}
+
+func (s Share) header() []byte {
+	b := []byte{shareVersion}
+	b = binary.BigEndian.AppendUint32(b, s.ID)
+	return append(b, s.Threshold, s.Index)
+}
+
+func (s Share) checksum() (sum [shareChecksumLen]byte) {
+	h := sha256.New()
+	h.Write(s.header())
+	h.Write(s.Value)
+	copy(sum[:], h.Sum(nil))
+	return sum
+}
+
+// interpolate evaluates at x the polynomials through the shares' points.
+func interpolate(shares []Share, x byte) []byte {
+	out := make([]byte, len(shares[0].Value))
+	for j, sj := range shares {
+		// Lagrange basis polynomial l_j(x) = prod (x - x_m) / (x_j - x_m).
+		// Subtraction in GF(256) is XOR.
+		l := byte(1)
+		for m, sm := range shares {
+			if m != j {
+				l = gfMul(l, gfMul(x^sm.Index, gfInv(sj.Index^sm.Index)))
+			}
+		}
+		for i, y := range sj.Value {
+			out[i] ^= gfMul(y, l)
+		}
+	}
+	return out
+}
+
+// gfEval evaluates a0 + coeffs[0]x + coeffs[1]x^2 + ... at x.
+func gfEval(a0 byte, coeffs []byte, x byte) byte {
+	var y byte
+	for i := len(coeffs) - 1; i >= 0; i-- {
+		y = gfMul(y, x) ^ coeffs[i]
+	}
+	return gfMul(y, x) ^ a0
+}
+
+// gfMul multiplies in GF(2^8) with the AES polynomial x^8+x^4+x^3+x+1,
+// without data-dependent branches or table lookups.
+func gfMul(a, b byte) byte {
+	var p byte
+	for range 8 {
+		p ^= -(b & 1) & a
+		a = a<<1 ^ 0x1b&-(a>>7)
+		b >>= 1
+	}
+	return p
+}
+
+// gfInv returns a^254, the multiplicative inverse of a non-zero a.
+func gfInv(a byte) byte {
+	// 254 = 0b11111110: square-and-multiply over the fixed exponent.
+	r := byte(1)
+	for range 7 {
+		a = gfMul(a, a)
+		r = gfMul(r, a)
+	}
+	return r
+}
@@ -0,0 +1,143 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestSplitCombineMnemonic(t *testing.T) {
+	m24, err := NewMnemonic(256)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, m := range []crypto.Mnemonic{refMnemonic, m24} {
+		shares, err := SplitMnemonic(m, 3, 5)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(shares) != 5 {
+			t.Fatalf("got %d shares, want 5", len(shares))
+		}
+		for _, subset := range [][]int{
+			{0, 1, 2},
+			{4, 2, 0},
+			{1, 3, 4},
+			{0, 1, 2, 3},
+			{4, 3, 2, 1, 0},
+		} {
+			picked := make([]Share, 0, len(subset))
+			for _, i := range subset {
+				picked = append(picked, shares[i])
+			}
+			got, err := CombineShares(picked)
+			if err != nil {
+				t.Fatalf("CombineShares(%v): %v", subset, err)
+			}
+			if got != m {
+				t.Fatalf("CombineShares(%v) returned a different phrase", subset)
+			}
+		}
+	}
+}
+
+func TestSplitMnemonicParams(t *testing.T) {
+	tests := []struct {
+		name              string
+		threshold, shares int
+	}{
+		{"threshold one", 1, 3},
+		{"threshold above shares", 4, 3},
+		{"too many shares", 2, 256},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := SplitMnemonic(refMnemonic, tt.threshold, tt.shares); !errors.Is(err, ErrInvalidShare) {
+				t.Fatalf("SplitMnemonic(%d, %d) = %v, want %v", tt.threshold, tt.shares, err, ErrInvalidShare)
+			}
+		})
+	}
+	if _, err := SplitMnemonic("not a phrase", 2, 3); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("SplitMnemonic(invalid) = %v, want %v", err, ErrInvalidMnemonic)
+	}
+}
+
+func TestCombineSharesDetectsBadShares(t *testing.T) {
+	shares, err := SplitMnemonic(refMnemonic, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := SplitMnemonic(refMnemonic, 2, 3)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	corrupted := shares[1]
+	corrupted.Value = append([]byte(nil), corrupted.Value...)
+	corrupted.Value[0] ^= 1
+
+	// A forged share with a valid checksum is caught by the extra share.
+	forged := corrupted
+	forged.Checksum = forged.checksum()
+
+	tests := []struct {
+		name   string
+		shares []Share
+	}{
+		{"none", nil},
+		{"below threshold", shares[:1]},
+		{"corrupted value", []Share{shares[0], corrupted}},
+		{"duplicate index", []Share{shares[0], shares[0]}},
+		{"different split", []Share{shares[0], other[1]}},
+		{"inconsistent extra share", []Share{shares[0], shares[2], forged}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := CombineShares(tt.shares); !errors.Is(err, ErrInvalidShare) {
+				t.Fatalf("CombineShares() = %v, want %v", err, ErrInvalidShare)
+			}
+		})
+	}
+}
+
+func TestShareStringRoundTrip(t *testing.T) {
+	shares, err := SplitMnemonic(refMnemonic, 2, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed := make([]Share, len(shares))
+	for i, s := range shares {
+		if parsed[i], err = ParseShare(s.String()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	got, err := CombineShares(parsed)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got != refMnemonic {
+		t.Fatal("parsed shares combined to a different phrase")
+	}
+
+	text := []byte(shares[0].String())
+	text[len(text)/2] ^= 'a' ^ 'b'
+	if _, err := ParseShare(string(text)); !errors.Is(err, ErrInvalidShare) {
+		t.Fatalf("ParseShare(altered) = %v, want %v", err, ErrInvalidShare)
+	}
+}
+
+func TestGF256Inverse(t *testing.T) {
+	for a := 1; a < 256; a++ {
+		if got := gfMul(byte(a), gfInv(byte(a))); got != 1 {
+			t.Fatalf("%#x * inv(%#x) = %#x, want 1", a, a, got)
+		}
+	}
+	// AES reference: {57} * {83} = {c1}.
+	if got := gfMul(0x57, 0x83); got != 0xc1 {
+		t.Fatalf("gfMul(0x57, 0x83) = %#x, want 0xc1", got)
+	}
+}
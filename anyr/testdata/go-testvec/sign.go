@@ -0,0 +1,115 @@
+// Signatures compatible with any-sync peers.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// ErrSignatureMismatch is returned by VerifyDetached when a signature was not
+// made by the account's identity key over the message.
+var ErrSignatureMismatch = errors.New("signature does not match account")
+
+// Sign signs payload with key, which must be an Ed25519 or secp256k1 key.
+// any-sync Ed25519 keys sign the payload directly (PureEdDSA, no prehash), so
+// signatures are interchangeable with those produced by any-sync itself.
+// secp256k1 keys sign as described on Secp256k1PrivKey. Other key types fail
+// with ErrUnsupportedKeyType.
+func Sign(key crypto.PrivKey, payload []byte) ([]byte, error) {
+	switch key.(type) {
+	case nil:
+		return nil, errors.New("sign: nil key")
+	case *crypto.Ed25519PrivKey, *Secp256k1PrivKey:
+		return key.Sign(payload)
+	default:
+		return nil, fmt.Errorf("%w: sign with %T", ErrUnsupportedKeyType, key)
+	}
+}
+
+// Verify reports whether sig is a valid signature of payload by pk, an
+// Ed25519 or secp256k1 public key. Other key types fail with
+// ErrUnsupportedKeyType.
+func Verify(pk crypto.PubKey, payload, sig []byte) (bool, error) {
+	switch pk.(type) {
+	case nil:
+		return false, errors.New("verify: nil public key")
+	case *crypto.Ed25519PubKey, *Secp256k1PubKey:
+		return pk.Verify(payload, sig)
+	default:
+		return false, fmt.Errorf("%w: verify with %T", ErrUnsupportedKeyType, pk)
+	}
+}
+
+// accountProofDomain tags account proof messages so that no choice of nonce
+// makes a proof signature valid for another message signed by the identity,
+// such as an any-sync handshake credential (localPeerId + remotePeerId).
+const accountProofDomain = "anytype-testvec/account-proof/v1"
+
+// SignAccountProof signs a proof that the caller holds the node's identity key.
+// The signed message is accountProofDomain followed by the identity's peer id
+// and the caller-supplied nonce, each prefixed with its 4-byte big-endian
+// length.
+func SignAccountProof(node *MasterNode, nonce []byte) ([]byte, error) {
+	if len(nonce) == 0 {
+		return nil, errors.New("account proof: empty nonce")
+	}
+	identity, priv := node.identity()
+	defer clear(priv)
+	return Sign(identity, accountProofMessage(identity.GetPublic().PeerId(), nonce))
+}
+
+// accountProofMessage builds the message signed by SignAccountProof.
+func accountProofMessage(peerID string, nonce []byte) []byte {
+	return domainMessage(accountProofDomain, []byte(peerID), nonce)
+}
+
+// domainMessage returns domain followed by each field prefixed with its
+// 4-byte big-endian length, so that distinct field lists never encode to the
+// same message and messages from different domains never collide.
+func domainMessage(domain string, fields ...[]byte) []byte {
+	n := len(domain)
+	for _, f := range fields {
+		n += 4 + len(f)
+	}
+	b := append(make([]byte, 0, n), domain...)
+	for _, f := range fields {
+		b = binary.BigEndian.AppendUint32(b, uint32(len(f)))
+		b = append(b, f...)
+	}
+	return b
+}
+
+// SignDetached signs message with the node's identity key, the key the
+// account id encodes, so anyone holding only the account id can check the
+// signature with VerifyDetached. The signature is plain Ed25519 over message.
+func SignDetached(node *MasterNode, message []byte) ([]byte, error) {
+	identity, priv := node.identity()
+	defer clear(priv)
+	return Sign(identity, message)
+}
+
+// VerifyDetached checks that signature was made over message by the identity
+// key of accountID. It needs only the public account id. A malformed account
+// id fails with ErrEncoding and a signature that does not verify fails with
+// ErrSignatureMismatch.
+func VerifyDetached(accountID string, message, signature []byte) error {
+	pk, err := AccountToPubKey(accountID)
+	if err != nil {
+		return err
+	}
+	ok, err := Verify(pk, message, signature)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrSignatureMismatch
+	}
+	return nil
+}
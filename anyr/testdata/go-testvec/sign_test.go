@@ -0,0 +1,250 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"encoding/hex"
+	"errors"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+func mustHex(t *testing.T, s string) []byte {
+	t.Helper()
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return b
+}
+
+func TestSignEd25519KnownAnswer(t *testing.T) {
+	// RFC 8032 section 7.1, test 2.
+	seed := mustHex(t, "4ccd089b28ff96da9db6c346ec114e0f5b8a319f35aba624da8cf6ed4fb8a6fb")
+	want := mustHex(t, "92a009a9f0d4cab8720e820b5f642540a2b27b5416503f8fb3762223ebdb69da085ac1e43e15996e458f3613d0f11d8c387b2eaeb4302aeeb00d291612bb0c00")
+	key := crypto.NewEd25519PrivKey(ed25519.NewKeyFromSeed(seed))
+	sig, err := Sign(key, []byte{0x72})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(sig, want) {
+		t.Fatalf("signature = %x, want %x", sig, want)
+	}
+	if ok, err := Verify(key.GetPublic(), []byte{0x72}, sig); err != nil || !ok {
+		t.Fatalf("Verify() = %v, %v; want true", ok, err)
+	}
+}
+
+func TestSignSecp256k1KnownAnswer(t *testing.T) {
+	// RFC 6979 nonce with SHA-256: private key 1, message "Satoshi Nakamoto".
+	key, err := NewSecp256k1PrivKey(mustHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("Satoshi Nakamoto")
+	sig, err := Sign(key, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, s := parsed.R(), parsed.S()
+	wantR := "934b1ea10a4b3c1757e2b0c017d0b6143ce3c9a7e6a4a49860d7a6ab210ee3d8"
+	wantS := "2442ce9d2b916064108014783e923ec36b49743e2ffa1c4496f01a512aafd9e5"
+	if rb, sb := r.Bytes(), s.Bytes(); hex.EncodeToString(rb[:]) != wantR || hex.EncodeToString(sb[:]) != wantS {
+		t.Fatalf("signature r=%x s=%x, want r=%s s=%s", rb, sb, wantR, wantS)
+	}
+	if ok, err := Verify(key.GetPublic(), msg, sig); err != nil || !ok {
+		t.Fatalf("Verify() = %v, %v; want true", ok, err)
+	}
+
+	// libp2p peers verify the same encoding.
+	p2pPub, err := key.GetPublic().LibP2P()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := p2pPub.Verify(msg, sig); err != nil || !ok {
+		t.Fatalf("libp2p Verify() = %v, %v; want true", ok, err)
+	}
+}
+
+func TestSecp256k1LowS(t *testing.T) {
+	key, err := NewSecp256k1PrivKey(mustHex(t, "0000000000000000000000000000000000000000000000000000000000000001"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	msg := []byte("Satoshi Nakamoto")
+	sig, err := Sign(key, msg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	low, err := ecdsa.ParseDERSignature(sig)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// (r, N-s) is the malleated twin of (r, s): mathematically valid, but
+	// not canonical.
+	r, s := low.R(), low.S()
+	s.Negate()
+	high := ecdsa.NewSignature(&r, &s)
+	if hs := high.S(); !hs.IsOverHalfOrder() {
+		t.Fatal("test signature is not high-S")
+	}
+	// Serialize canonicalizes S, so the high form is DER encoded by hand.
+	if ok, err := Verify(key.GetPublic(), msg, derSignature(high)); err != nil || ok {
+		t.Fatalf("Verify(high-S) = %v, %v; want false", ok, err)
+	}
+	if got := normalizeLowS(high); !got.IsEqual(low) {
+		t.Fatal("normalizeLowS did not restore the low-S signature")
+	}
+	if got := normalizeLowS(low); got != low {
+		t.Fatal("normalizeLowS changed a low-S signature")
+	}
+}
+
+// derSignature DER encodes sig without canonicalizing S.
+func derSignature(sig *ecdsa.Signature) []byte {
+	integer := func(v [32]byte) []byte {
+		b := bytes.TrimLeft(v[:], "\x00")
+		if len(b) == 0 || b[0]&0x80 != 0 {
+			b = append([]byte{0}, b...)
+		}
+		return append([]byte{0x02, byte(len(b))}, b...)
+	}
+	r, s := sig.R(), sig.S()
+	body := append(integer(r.Bytes()), integer(s.Bytes())...)
+	return append([]byte{0x30, byte(len(body))}, body...)
+}
+
+func TestSignUnsupportedKeyType(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Sign(notEd25519{res.Identity()}, []byte("x")); !errors.Is(err, ErrUnsupportedKeyType) {
+		t.Fatalf("Sign() = %v, want %v", err, ErrUnsupportedKeyType)
+	}
+	if _, err := NewSecp256k1PrivKey(make([]byte, 32)); !errors.Is(err, ErrEncoding) {
+		t.Fatalf("NewSecp256k1PrivKey(0) = %v, want %v", err, ErrEncoding)
+	}
+}
+
+func TestSignVerify(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload := []byte("anytype sign test payload")
+	sig, err := Sign(res.Identity(), payload)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := Verify(res.Identity().GetPublic(), payload, sig)
+	if err != nil || !ok {
+		t.Fatalf("Verify() = %v, %v; want true", ok, err)
+	}
+
+	tampered := append([]byte(nil), payload...)
+	tampered[0] ^= 0x01
+	if ok, _ := Verify(res.Identity().GetPublic(), tampered, sig); ok {
+		t.Fatal("signature verified for a tampered payload")
+	}
+}
+
+func TestSignAccountProof(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	nonce := []byte("0123456789abcdef")
+	sig, err := SignAccountProof(node, nonce)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pk, err := AccountToPubKey(refAccountID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ok, err := Verify(pk, accountProofMessage(pk.PeerId(), nonce), sig)
+	if err != nil || !ok {
+		t.Fatalf("account proof did not verify: %v, %v", ok, err)
+	}
+	if ok, _ := Verify(pk, accountProofMessage(pk.PeerId(), []byte("fedcba9876543210")), sig); ok {
+		t.Fatal("account proof verified for a different nonce")
+	}
+	if _, err := SignAccountProof(node, nil); err == nil {
+		t.Fatal("SignAccountProof accepted an empty nonce")
+	}
+
+	// A nonce chosen as a remote peer id must not yield a signature over the
+	// any-sync handshake credential message localPeerId + remotePeerId.
+	remote := []byte("12D3KooWMUz8P5eWo5kEVu9yESF5PPVrVPo2K3S33pA8c4qhTTnf")
+	sig, err = SignAccountProof(node, remote)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ok, _ := Verify(pk, append([]byte(pk.PeerId()), remote...), sig); ok {
+		t.Fatal("account proof verified as a handshake credential signature")
+	}
+}
+
+func TestDomainMessage(t *testing.T) {
+	got := domainMessage("d", []byte("ab"), nil)
+	want := []byte("d\x00\x00\x00\x02ab\x00\x00\x00\x00")
+	if !bytes.Equal(got, want) {
+		t.Fatalf("domainMessage() = %q, want %q", got, want)
+	}
+	if bytes.Equal(domainMessage("d", []byte("ab"), []byte("c")), domainMessage("d", []byte("a"), []byte("bc"))) {
+		t.Fatal("field boundaries do not change the message")
+	}
+}
+
+func TestSignDetached(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	manifest := []byte("release v1.2.3 sha256:0123abcd")
+	sig, err := SignDetached(node, manifest)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The verifier only has the published account id.
+	if err := VerifyDetached(refAccountID, manifest, sig); err != nil {
+		t.Fatalf("VerifyDetached() = %v", err)
+	}
+
+	other, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tampered := append([]byte(nil), manifest...)
+	tampered[0] ^= 0x01
+	tests := []struct {
+		name      string
+		accountID string
+		message   []byte
+		sig       []byte
+		want      error
+	}{
+		{"tampered message", refAccountID, tampered, sig, ErrSignatureMismatch},
+		{"other account", other.AccountID, manifest, sig, ErrSignatureMismatch},
+		{"truncated signature", refAccountID, manifest, sig[:len(sig)-1], ErrSignatureMismatch},
+		{"malformed account id", "A" + refAccountID[2:], manifest, sig, ErrEncoding},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := VerifyDetached(tt.accountID, tt.message, tt.sig); !errors.Is(err, tt.want) {
+				t.Fatalf("VerifyDetached() = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+// Offline construction of any-sync space creation records.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"strconv"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// anytypeSpaceType is the space type Anytype clients create regular spaces
+// with (spacecore.SpaceType in anytype-heart).
+const anytypeSpaceType = "anytype.space"
+
+// SpaceCreatePayload holds the records that create a space, in the forms
+// any-sync stores and sends them.
+type SpaceCreatePayload struct {
+	// SpaceID is the space id: the CID of Header's raw header, a dot, and the
+	// replication key in base 36.
+	SpaceID string
+	// Header is a marshaled spacesyncproto.RawSpaceHeaderWithId.
+	Header []byte
+	// AclRootID is the id of the ACL root record, the CID of its raw record.
+	AclRootID string
+	// AclRoot is a marshaled consensusproto.RawRecordWithId holding the ACL
+	// root, the first record of the space's ACL log.
+	AclRoot []byte
+}
+
+// BuildSpaceCreateRecord builds and signs the space header and ACL root of a
+// new space owned by the node's account, as any-sync's
+// spacepayloads.StoragePayloadForSpaceCreate and AclRecordBuilder.BuildRoot
+// do. The identity key signs both records and the master key signs the
+// identity, so the ACL root carries the same owner proofs a client's does.
+//
+// spaceKey becomes the space's metadata key. The header seed and replication
+// key are derived from its public half, so the space id depends only on the
+// node, spaceKey and the timestamp, which is the current second. The clock
+// is read once, so the header and the ACL root carry the same timestamp. The
+// read key is freshly random and is stored in the ACL root encrypted to the
+// identity.
+//
+// The settings tree root that a client also pushes when it first syncs a
+// space is not built here.
+func BuildSpaceCreateRecord(node *MasterNode, spaceKey crypto.PrivKey) (*SpaceCreatePayload, error) {
+	return buildSpaceCreateRecord(node, spaceKey, time.Now().Unix())
+}
+
+// buildSpaceCreateRecord is BuildSpaceCreateRecord with the timestamp, in Unix
+// seconds, that both records carry.
+func buildSpaceCreateRecord(node *MasterNode, spaceKey crypto.PrivKey, timestamp int64) (*SpaceCreatePayload, error) {
+	if spaceKey == nil {
+		return nil, errors.New("build space: nil space key")
+	}
+	identity, identityPriv := node.identity()
+	defer clear(identityPriv)
+	master, masterPriv := node.node.privKey()
+	defer clear(masterPriv)
+
+	identityProto, err := identity.GetPublic().Marshall()
+	if err != nil {
+		return nil, err
+	}
+	spacePub, err := spaceKey.GetPublic().Raw()
+	if err != nil {
+		return nil, err
+	}
+	seed := sha256.Sum256(append([]byte("anytype space seed"), spacePub...))
+	repKey := fnv.New64()
+	repKey.Write(spacePub)
+
+	header, err := (&spacesyncproto.SpaceHeader{
+		Identity:       identityProto,
+		Timestamp:      timestamp,
+		SpaceType:      anytypeSpaceType,
+		ReplicationKey: repKey.Sum64(),
+		Seed:           seed[:],
+	}).MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	headerSig, err := identity.Sign(header)
+	if err != nil {
+		return nil, err
+	}
+	rawHeader, err := (&spacesyncproto.RawSpaceHeader{SpaceHeader: header, Signature: headerSig}).MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+	headerCID, err := cidutil.NewCidFromBytes(rawHeader)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	spaceID := headerCID + "." + strconv.FormatUint(repKey.Sum64(), 36)
+	headerWithID, err := (&spacesyncproto.RawSpaceHeaderWithId{RawHeader: rawHeader, Id: spaceID}).MarshalVT()
+	if err != nil {
+		return nil, err
+	}
+
+	aclID, acl, err := buildAclRoot(identity, master, spaceKey, spaceID, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return &SpaceCreatePayload{SpaceID: spaceID, Header: headerWithID, AclRootID: aclID, AclRoot: acl}, nil
+}
+
+// buildAclRoot builds the signed ACL root of spaceID with a new random read key.
+func buildAclRoot(identity, master, metadataKey crypto.PrivKey, spaceID string, timestamp int64) (string, []byte, error) {
+	identityRaw, err := identity.GetPublic().Raw()
+	if err != nil {
+		return "", nil, err
+	}
+	identityProto, err := identity.GetPublic().Marshall()
+	if err != nil {
+		return "", nil, err
+	}
+	masterProto, err := master.GetPublic().Marshall()
+	if err != nil {
+		return "", nil, err
+	}
+	identitySig, err := master.Sign(identityRaw)
+	if err != nil {
+		return "", nil, err
+	}
+	readKey, err := crypto.NewRandomAES()
+	if err != nil {
+		return "", nil, err
+	}
+	readKeyProto, err := readKey.Marshall()
+	if err != nil {
+		return "", nil, err
+	}
+	defer clear(readKeyProto)
+	encryptedReadKey, err := identity.GetPublic().Encrypt(readKeyProto)
+	if err != nil {
+		return "", nil, err
+	}
+	metadataPrivProto, err := metadataKey.Marshall()
+	if err != nil {
+		return "", nil, err
+	}
+	defer clear(metadataPrivProto)
+	encryptedMetadataPriv, err := readKey.Encrypt(metadataPrivProto)
+	if err != nil {
+		return "", nil, err
+	}
+	metadataPubProto, err := metadataKey.GetPublic().Marshall()
+	if err != nil {
+		return "", nil, err
+	}
+	encryptedOwnerMetadata, err := metadataKey.GetPublic().Encrypt(nil)
+	if err != nil {
+		return "", nil, err
+	}
+
+	root, err := (&aclrecordproto.AclRoot{
+		Identity:                 identityProto,
+		MasterKey:                masterProto,
+		SpaceId:                  spaceID,
+		EncryptedReadKey:         encryptedReadKey,
+		Timestamp:                timestamp,
+		IdentitySignature:        identitySig,
+		MetadataPubKey:           metadataPubProto,
+		EncryptedMetadataPrivKey: encryptedMetadataPriv,
+		EncryptedOwnerMetadata:   encryptedOwnerMetadata,
+	}).MarshalVT()
+	if err != nil {
+		return "", nil, err
+	}
+	rootSig, err := identity.Sign(root)
+	if err != nil {
+		return "", nil, err
+	}
+	raw, err := (&consensusproto.RawRecord{Payload: root, Signature: rootSig}).MarshalVT()
+	if err != nil {
+		return "", nil, err
+	}
+	id, err := cidutil.NewCidFromBytes(raw)
+	if err != nil {
+		return "", nil, fmt.Errorf("%w: %w", ErrEncoding, err)
+	}
+	withID, err := (&consensusproto.RawRecordWithId{Payload: raw, Id: id}).MarshalVT()
+	if err != nil {
+		return "", nil, err
+	}
+	return id, withID, nil
+}
@@ -0,0 +1,193 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/anyproto/any-sync/commonspace/object/acl/aclrecordproto"
+	"github.com/anyproto/any-sync/commonspace/spacesyncproto"
+	"github.com/anyproto/any-sync/consensus/consensusproto"
+	"github.com/anyproto/any-sync/util/cidutil"
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// testSpaceTimestamp is the record timestamp used by the space tests.
+const testSpaceTimestamp = 1_700_000_000
+
+func testSpaceKey(t *testing.T, seed byte) crypto.PrivKey {
+	t.Helper()
+	key, _, err := crypto.GenerateEd25519Key(bytes.NewReader(bytes.Repeat([]byte{seed}, 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key
+}
+
+func TestBuildSpaceCreateRecord(t *testing.T) {
+	res, err := DeriveKeys(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	spaceKey := testSpaceKey(t, 7)
+	p, err := buildSpaceCreateRecord(res.MasterNode(), spaceKey, testSpaceTimestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Header: signed by the identity, id is CID(raw header).base36(repKey).
+	var withID spacesyncproto.RawSpaceHeaderWithId
+	if err := withID.UnmarshalVT(p.Header); err != nil {
+		t.Fatal(err)
+	}
+	if withID.Id != p.SpaceID {
+		t.Fatalf("header id %s, SpaceID %s", withID.Id, p.SpaceID)
+	}
+	var raw spacesyncproto.RawSpaceHeader
+	if err := raw.UnmarshalVT(withID.RawHeader); err != nil {
+		t.Fatal(err)
+	}
+	var header spacesyncproto.SpaceHeader
+	if err := header.UnmarshalVT(raw.SpaceHeader); err != nil {
+		t.Fatal(err)
+	}
+	owner, err := crypto.UnmarshalEd25519PublicKeyProto(header.Identity)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owner.Account() != refAccountID {
+		t.Fatalf("header identity %s, want %s", owner.Account(), refAccountID)
+	}
+	if ok, err := owner.Verify(raw.SpaceHeader, raw.Signature); err != nil || !ok {
+		t.Fatalf("header signature does not verify: %v, %v", ok, err)
+	}
+	cid, repKey, ok := strings.Cut(p.SpaceID, ".")
+	if !ok || !cidutil.VerifyCid(withID.RawHeader, cid) {
+		t.Fatalf("space id %s is not the CID of the raw header", p.SpaceID)
+	}
+	if repKey != strconv.FormatUint(header.ReplicationKey, 36) {
+		t.Fatalf("space id suffix %s, replication key %d", repKey, header.ReplicationKey)
+	}
+	if header.SpaceType != anytypeSpaceType || header.Timestamp != testSpaceTimestamp {
+		t.Fatalf("header type %q timestamp %d", header.SpaceType, header.Timestamp)
+	}
+
+	// ACL root: signed by the identity, identity signed by the master key,
+	// read key readable by the owner and metadata key recoverable with it.
+	var aclWithID consensusproto.RawRecordWithId
+	if err := aclWithID.UnmarshalVT(p.AclRoot); err != nil {
+		t.Fatal(err)
+	}
+	if aclWithID.Id != p.AclRootID || !cidutil.VerifyCid(aclWithID.Payload, p.AclRootID) {
+		t.Fatalf("ACL root id %s does not match its record", p.AclRootID)
+	}
+	var rec consensusproto.RawRecord
+	if err := rec.UnmarshalVT(aclWithID.Payload); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := owner.Verify(rec.Payload, rec.Signature); err != nil || !ok {
+		t.Fatalf("ACL root signature does not verify: %v, %v", ok, err)
+	}
+	var root aclrecordproto.AclRoot
+	if err := root.UnmarshalVT(rec.Payload); err != nil {
+		t.Fatal(err)
+	}
+	if root.SpaceId != p.SpaceID || !bytes.Equal(root.Identity, header.Identity) {
+		t.Fatalf("ACL root space %s identity differs from header", root.SpaceId)
+	}
+	if root.Timestamp != header.Timestamp {
+		t.Fatalf("ACL root timestamp %d, header %d", root.Timestamp, header.Timestamp)
+	}
+	masterPub, err := crypto.UnmarshalEd25519PublicKeyProto(root.MasterKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !masterPub.Equals(res.MasterKey().GetPublic()) {
+		t.Fatal("ACL root master key is not the account master key")
+	}
+	ownerRaw, _ := owner.Raw()
+	if ok, err := masterPub.Verify(ownerRaw, root.IdentitySignature); err != nil || !ok {
+		t.Fatal("identity signature does not verify with the master key")
+	}
+	readKeyProto, err := res.Identity().Decrypt(root.EncryptedReadKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	readKey, err := crypto.UnmarshallAESKeyProto(readKeyProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadataProto, err := readKey.Decrypt(root.EncryptedMetadataPrivKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	metadataKey, err := crypto.UnmarshalEd25519PrivateKeyProto(metadataProto)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !metadataKey.Equals(spaceKey) {
+		t.Fatal("ACL root metadata key is not the space key")
+	}
+}
+
+func TestBuildSpaceCreateRecordStableID(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	a, err := buildSpaceCreateRecord(node, testSpaceKey(t, 7), testSpaceTimestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	b, err := buildSpaceCreateRecord(node, testSpaceKey(t, 7), testSpaceTimestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.SpaceID != b.SpaceID || !bytes.Equal(a.Header, b.Header) {
+		t.Fatalf("space id not stable: %s, %s", a.SpaceID, b.SpaceID)
+	}
+	// Pinned so that a change to the header layout is noticed.
+	if want := "bafyreifgd2qzolvr32kt7whaje2r6tkmmej3ar2g2fhqcwsrfqoweyigeu.38avqvfvlr3vx"; a.SpaceID != want {
+		t.Fatalf("space id = %s, want %s", a.SpaceID, want)
+	}
+	other, err := buildSpaceCreateRecord(node, testSpaceKey(t, 8), testSpaceTimestamp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if other.SpaceID == a.SpaceID {
+		t.Fatal("different space keys gave the same space id")
+	}
+}
+
+func TestBuildSpaceCreateRecordUsesClock(t *testing.T) {
+	node, err := DeriveMasterNode(crypto.Mnemonic(refMnemonic), 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before := time.Now().Unix()
+	p, err := BuildSpaceCreateRecord(node, testSpaceKey(t, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	after := time.Now().Unix()
+	var withID spacesyncproto.RawSpaceHeaderWithId
+	if err := withID.UnmarshalVT(p.Header); err != nil {
+		t.Fatal(err)
+	}
+	var raw spacesyncproto.RawSpaceHeader
+	if err := raw.UnmarshalVT(withID.RawHeader); err != nil {
+		t.Fatal(err)
+	}
+	var header spacesyncproto.SpaceHeader
+	if err := header.UnmarshalVT(raw.SpaceHeader); err != nil {
+		t.Fatal(err)
+	}
+	if header.Timestamp < before || header.Timestamp > after {
+		t.Fatalf("header timestamp %d outside %d..%d", header.Timestamp, before, after)
+	}
+}
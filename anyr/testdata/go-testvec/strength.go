@@ -0,0 +1,61 @@
+// Advisory mnemonic strength estimate.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// MnemonicStrength reports the nominal entropy of an English phrase in bits
+// (128 for 12 words up to 256 for 24) and advisory warnings about patterns
+// that suggest the words were not chosen at random. An invalid phrase reports
+// 0 bits and a single warning.
+//
+// The checks are a UX nudge, not a security guarantee: they catch repeated
+// words, runs of adjacent wordlist entries and repetitive entropy bytes, but a
+// phrase picked by hand to read as a sentence can pass them all. Warnings name
+// word positions, never the words, so they are safe to log.
+func MnemonicStrength(m crypto.Mnemonic) (bits int, warnings []string) {
+	words := strings.Split(string(m), " ")
+	entropy, err := English.entropy(words)
+	if err != nil {
+		return 0, []string{"phrase is not a valid BIP39 mnemonic"}
+	}
+	defer clear(entropy)
+
+	first := make(map[string]int, len(words))
+	for i, w := range words {
+		if j, ok := first[w]; ok {
+			warnings = append(warnings, fmt.Sprintf("word %d repeats word %d", i+1, j+1))
+			continue
+		}
+		first[w] = i
+	}
+
+	// Walking the wordlist in order (abandon, ability, able, ...) gives
+	// neighbours whose indices differ by one.
+	adjacent := 0
+	for i := 1; i < len(words); i++ {
+		if d := English.index[words[i]] - English.index[words[i-1]]; d == 1 || d == -1 {
+			adjacent++
+		}
+	}
+	if adjacent >= len(words)/3 {
+		warnings = append(warnings, fmt.Sprintf("%d neighbouring words are adjacent in the wordlist", adjacent))
+	}
+
+	distinct := make(map[byte]struct{}, len(entropy))
+	for _, b := range entropy {
+		distinct[b] = struct{}{}
+	}
+	if len(distinct) <= len(entropy)/4 {
+		warnings = append(warnings, fmt.Sprintf("entropy uses only %d distinct byte values", len(distinct)))
+	}
+	return len(entropy) * 8, warnings
+}
@@ -0,0 +1,52 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+func TestMnemonicStrength(t *testing.T) {
+	tests := []struct {
+		name     string
+		phrase   string
+		bits     int
+		warnings int
+	}{
+		{"reference", refMnemonic, 128, 0},
+		{"24 words", "trade service material unusual answer render elevator matrix leopard today bubble family away police congress sad talk ghost upset say shed arrow amused rich", 256, 0},
+		{"repeating entropy", "letter advice cage absurd amount doctor acoustic avoid letter advice cage absurd amount doctor acoustic avoid letter always", 192, 2},
+		{"invalid", "tag volcano", 0, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bits, warnings := MnemonicStrength(crypto.Mnemonic(tt.phrase))
+			if bits != tt.bits {
+				t.Fatalf("bits = %d, want %d", bits, tt.bits)
+			}
+			if tt.warnings == 0 && len(warnings) != 0 || tt.warnings != 0 && len(warnings) < tt.warnings {
+				t.Fatalf("warnings = %q, want %d", warnings, tt.warnings)
+			}
+		})
+	}
+}
+
+func TestMnemonicStrengthDuplicateWords(t *testing.T) {
+	const phrase = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+	bits, warnings := MnemonicStrength(crypto.Mnemonic(phrase))
+	if bits != 128 {
+		t.Fatalf("bits = %d, want 128", bits)
+	}
+	if len(warnings) == 0 || !strings.Contains(warnings[0], "word 2 repeats word 1") {
+		t.Fatalf("warnings = %q, want a repeated word warning", warnings)
+	}
+	for _, w := range warnings {
+		if strings.Contains(w, "abandon") {
+			t.Fatalf("warning %q contains a mnemonic word", w)
+		}
+	}
+}
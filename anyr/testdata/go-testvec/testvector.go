@@ -0,0 +1,104 @@
+// Cross-language test vector fixtures.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// TestVectorsFile is the fixture consumed by both this package and the Rust
+// derivation tests. Bump the version suffix when the vector format changes.
+const TestVectorsFile = "testdata/testvectors-v1.json"
+
+// TestVector is one mnemonic/index derivation. Fields are declared in
+// alphabetical order of their JSON names so the encoding has sorted keys.
+type TestVector struct {
+	// AccountID is the Anytype account id of the identity key.
+	AccountID string `json:"account_id"`
+	// AccountKey is base64(key || chain_code) of the node at m/44'/2046'/index'.
+	AccountKey string `json:"account_key"`
+	Index      int    `json:"index"`
+	Mnemonic   string `json:"mnemonic"`
+	// SigningPubKey is the base64 raw public key of the master key at m/44'/2046'/index'.
+	SigningPubKey string `json:"signing_pubkey"`
+}
+
+// GenerateTestVectors derives a vector for every combination of mnemonic and
+// index, in mnemonic-major order.
+func GenerateTestVectors(mnemonics []string, indices []int) ([]TestVector, error) {
+	for _, index := range indices {
+		if err := checkIndex(index); err != nil {
+			return nil, err
+		}
+	}
+
+	vectors := make([]TestVector, 0, len(mnemonics)*len(indices))
+	for _, phrase := range mnemonics {
+		prefixes, err := deriveAccountPrefixes(context.Background(), crypto.Mnemonic(phrase))
+		if err != nil {
+			return nil, err
+		}
+		for _, index := range indices {
+			res := prefixes.keys(uint32(index))
+			nodeBytes, err := res.MasterNode().MarshalBinary()
+			if err != nil {
+				return nil, err
+			}
+			signingPub, err := res.MasterKey().GetPublic().Raw()
+			if err != nil {
+				return nil, err
+			}
+			vectors = append(vectors, TestVector{
+				AccountID:     res.AccountID,
+				AccountKey:    base64.StdEncoding.EncodeToString(nodeBytes),
+				Index:         index,
+				Mnemonic:      phrase,
+				SigningPubKey: base64.StdEncoding.EncodeToString(signingPub),
+			})
+			res.Wipe()
+		}
+		prefixes.wipe()
+	}
+	return vectors, nil
+}
+
+// MarshalTestVectors encodes vectors as canonical JSON: sorted keys, two-space
+// indentation and a trailing newline.
+func MarshalTestVectors(vectors []TestVector) ([]byte, error) {
+	data, err := json.MarshalIndent(vectors, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return append(data, '\n'), nil
+}
+
+// LoadTestVectors reads a fixture written by WriteTestVectors.
+func LoadTestVectors(path string) ([]TestVector, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	var vectors []TestVector
+	if err := json.Unmarshal(data, &vectors); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrEncoding, path, err)
+	}
+	return vectors, nil
+}
+
+// WriteTestVectors writes vectors to path in canonical JSON.
+func WriteTestVectors(path string, vectors []TestVector) error {
+	data, err := MarshalTestVectors(vectors)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(path, data, 0o644)
+}
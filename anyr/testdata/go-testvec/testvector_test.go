@@ -0,0 +1,94 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"bytes"
+	"encoding/base64"
+	"os"
+	"slices"
+	"testing"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// TestTestVectorsFixture re-derives every vector in the fixture and requires
+// the canonical encoding to match the file byte for byte. Regenerate with
+// `go run ./cmd/go-testvec -write` if the vector set deliberately changes.
+func TestTestVectorsFixture(t *testing.T) {
+	want, err := os.ReadFile(TestVectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fixture, err := LoadTestVectors(TestVectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var mnemonics []string
+	var indices []int
+	for _, v := range fixture {
+		if !slices.Contains(mnemonics, v.Mnemonic) {
+			mnemonics = append(mnemonics, v.Mnemonic)
+		}
+		if !slices.Contains(indices, v.Index) {
+			indices = append(indices, v.Index)
+		}
+	}
+	vectors, err := GenerateTestVectors(mnemonics, indices)
+	if err != nil {
+		t.Fatal(err)
+	}
+	got, err := MarshalTestVectors(vectors)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(got, want) {
+		t.Fatalf("re-derived vectors differ from %s:\n%s", TestVectorsFile, got)
+	}
+}
+
+func TestTestVectorsReference(t *testing.T) {
+	vectors, err := GenerateTestVectors([]string{refMnemonic}, []int{0})
+	if err != nil {
+		t.Fatal(err)
+	}
+	const wantKey = "2x9TiDKFCAl79l5llFLvI4yU3P8KImRCm/STVr/iIU+leXyZof6C8KRr0666JX7wFvWprtOqnmK+W/1TTYWiTg=="
+	if v := vectors[0]; v.AccountKey != wantKey || v.AccountID != refAccountID {
+		t.Fatalf("unexpected reference vector: %+v", v)
+	}
+}
+
+// TestTestVectorsMatchAnySync checks every fixture vector against any-sync's
+// own derivation, so the SLIP-10 and PBKDF2 code in this package stays tied
+// to the reference implementation.
+func TestTestVectorsMatchAnySync(t *testing.T) {
+	fixture, err := LoadTestVectors(TestVectorsFile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range fixture {
+		ref, err := crypto.Mnemonic(v.Mnemonic).DeriveKeys(uint32(v.Index))
+		if err != nil {
+			t.Fatal(err)
+		}
+		node, err := ref.MasterNode.MarshalBinary()
+		if err != nil {
+			t.Fatal(err)
+		}
+		signingPub, err := ref.MasterKey.GetPublic().Raw()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := ref.Identity.GetPublic().Account(); got != v.AccountID {
+			t.Errorf("index %d: any-sync account id %s, fixture %s", v.Index, got, v.AccountID)
+		}
+		if got := base64.StdEncoding.EncodeToString(node); got != v.AccountKey {
+			t.Errorf("index %d: any-sync account key %s, fixture %s", v.Index, got, v.AccountKey)
+		}
+		if got := base64.StdEncoding.EncodeToString(signingPub); got != v.SigningPubKey {
+			t.Errorf("index %d: any-sync signing key %s, fixture %s", v.Index, got, v.SigningPubKey)
+		}
+	}
+}
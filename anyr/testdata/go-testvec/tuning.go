@@ -0,0 +1,43 @@
+// Derivation timing for capacity planning.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"slices"
+	"time"
+
+	"github.com/anyproto/any-sync/util/crypto"
+)
+
+// benchmarkMnemonic is the all-zero-entropy BIP39 phrase, a fixed throwaway
+// input so timings are comparable across runs and hosts.
+const benchmarkMnemonic = "abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon abandon about"
+
+// BenchmarkDerivation times samples calls to DeriveKeys for account 0 of a fixed
+// throwaway phrase and returns the mean and 99th percentile duration. The calls
+// run one after another on the calling goroutine, so the result is the cost
+// per core; divide a core's time budget by p99 to size a worker pool. A
+// samples below 1 is treated as 1. If a derivation fails, for example because
+// SetKDF installed a broken KDF, its error is returned.
+func BenchmarkDerivation(samples int) (avg, p99 time.Duration, err error) {
+	samples = max(samples, 1)
+	times := make([]time.Duration, samples)
+	var total time.Duration
+	for i := range times {
+		start := time.Now()
+		res, err := DeriveKeys(crypto.Mnemonic(benchmarkMnemonic), 0)
+		times[i] = time.Since(start)
+		if err != nil {
+			return 0, 0, err
+		}
+		res.Wipe()
+		total += times[i]
+	}
+	slices.Sort(times)
+	// Nearest-rank percentile: the ceil(0.99*samples)-th smallest sample.
+	rank := (99*samples + 99) / 100
+	return total / time.Duration(samples), times[rank-1], nil
+}
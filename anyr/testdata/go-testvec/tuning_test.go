@@ -0,0 +1,29 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBenchmarkDerivation(t *testing.T) {
+	for _, samples := range []int{0, 1, 4} {
+		avg, p99, err := BenchmarkDerivation(samples)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if avg <= 0 || p99 < avg {
+			t.Fatalf("BenchmarkDerivation(%d) = avg %v, p99 %v; want 0 < avg <= p99", samples, avg, p99)
+		}
+	}
+}
+
+func TestBenchmarkDerivationError(t *testing.T) {
+	SetKDF(shortKDF{})
+	t.Cleanup(func() { SetKDF(nil) })
+	if _, _, err := BenchmarkDerivation(2); !errors.Is(err, ErrDerivation) {
+		t.Fatalf("BenchmarkDerivation with short KDF output = %v, want %v", err, ErrDerivation)
+	}
+}
@@ -0,0 +1,56 @@
+// any-sync version checks.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"fmt"
+	"runtime/debug"
+)
+
+// AnySyncVersion is the any-sync release the derivation was checked against
+// and the fixture in TestVectorsFile was generated with. Update it whenever
+// the fixture is regenerated against a new any-sync.
+const AnySyncVersion = "v0.11.14"
+
+// anySyncModule is the module path of any-sync.
+const anySyncModule = "github.com/anyproto/any-sync"
+
+// ErrIncompatibleVersion is returned by CheckCompatibility.
+var ErrIncompatibleVersion = errors.New("incompatible any-sync version")
+
+// ProtocolVersion returns the version of any-sync linked into the running
+// binary, as recorded in its build info, or "" if the binary has no module
+// information.
+func ProtocolVersion() string {
+	info, ok := debug.ReadBuildInfo()
+	if !ok {
+		return ""
+	}
+	for _, dep := range info.Deps {
+		if dep.Path != anySyncModule {
+			continue
+		}
+		if dep.Replace != nil {
+			dep = dep.Replace
+		}
+		return dep.Version
+	}
+	return ""
+}
+
+// CheckCompatibility fails with ErrIncompatibleVersion unless expected is
+// AnySyncVersion and the linked any-sync, if known, is that same version. Call
+// it at startup to fail loudly rather than derive different keys.
+func CheckCompatibility(expected string) error {
+	if expected != AnySyncVersion {
+		return fmt.Errorf("%w: expected %s, test vectors were generated with %s", ErrIncompatibleVersion, expected, AnySyncVersion)
+	}
+	if linked := ProtocolVersion(); linked != "" && linked != AnySyncVersion {
+		return fmt.Errorf("%w: built with %s, test vectors were generated with %s", ErrIncompatibleVersion, linked, AnySyncVersion)
+	}
+	return nil
+}
@@ -0,0 +1,39 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"errors"
+	"os"
+	"regexp"
+	"testing"
+)
+
+func TestAnySyncVersionMatchesGoMod(t *testing.T) {
+	data, err := os.ReadFile("go.mod")
+	if err != nil {
+		t.Fatal(err)
+	}
+	m := regexp.MustCompile(`(?m)^\s*` + regexp.QuoteMeta(anySyncModule) + `\s+(\S+)`).FindSubmatch(data)
+	if m == nil {
+		t.Fatalf("%s not required in go.mod", anySyncModule)
+	}
+	if string(m[1]) != AnySyncVersion {
+		t.Fatalf("go.mod requires any-sync %s, AnySyncVersion is %s: regenerate the fixture and update the constant", m[1], AnySyncVersion)
+	}
+	if v := ProtocolVersion(); v != "" && v != AnySyncVersion {
+		t.Fatalf("ProtocolVersion() = %s, want %s", v, AnySyncVersion)
+	}
+}
+
+func TestCheckCompatibility(t *testing.T) {
+	if err := CheckCompatibility(AnySyncVersion); err != nil {
+		t.Fatal(err)
+	}
+	for _, v := range []string{"", "v0.4.0", "v0.11.15"} {
+		if err := CheckCompatibility(v); !errors.Is(err, ErrIncompatibleVersion) {
+			t.Fatalf("CheckCompatibility(%q) = %v, want %v", v, err, ErrIncompatibleVersion)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+// BIP39 wordlists.
+//
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/anyproto/go-bip39/wordlists"
+	"golang.org/x/text/unicode/norm"
+)
+
+// wordlistSize is the number of words in every BIP39 wordlist.
+const wordlistSize = 2048
+
+// Wordlist is a BIP39 wordlist. Words are compared after NFKD normalization,
+// which BIP39 requires for both the checksum lookup and the seed input.
+type Wordlist struct {
+	// Name identifies the list in the registry, e.g. "english".
+	Name string
+	// Separator joins words when a phrase is displayed. Japanese phrases use
+	// the ideographic space U+3000; NFKD maps it to U+0020 before seeding.
+	Separator string
+	words     []string
+	index     map[string]int
+}
+
+// English is the BIP39 English wordlist, the only list Anytype generates.
+var English = mustWordlist("english", wordlists.English, " ")
+
+var (
+	wordlistsMu sync.RWMutex
+	registry    = map[string]*Wordlist{English.Name: English}
+)
+
+// NewWordlist builds a wordlist from exactly 2048 distinct words.
+func NewWordlist(name string, words []string, separator string) (*Wordlist, error) {
+	if len(words) != wordlistSize {
+		return nil, fmt.Errorf("wordlist %q has %d words, want %d", name, len(words), wordlistSize)
+	}
+	wl := &Wordlist{
+		Name:      name,
+		Separator: separator,
+		words:     make([]string, len(words)),
+		index:     make(map[string]int, len(words)),
+	}
+	for i, w := range words {
+		w = norm.NFKD.String(w)
+		if _, dup := wl.index[w]; dup {
+			return nil, fmt.Errorf("wordlist %q repeats word %d", name, i)
+		}
+		wl.words[i] = w
+		wl.index[w] = i
+	}
+	return wl, nil
+}
+
+func mustWordlist(name string, words []string, separator string) *Wordlist {
+	wl, err := NewWordlist(name, words, separator)
+	if err != nil {
+		panic(err)
+	}
+	return wl
+}
+
+// RegisterWordlist makes wl available from WordlistByName. For example, to
+// accept Japanese phrases:
+//
+//	ja, _ := NewWordlist("japanese", wordlists.Japanese, "　")
+//	RegisterWordlist(ja)
+func RegisterWordlist(wl *Wordlist) {
+	wordlistsMu.Lock()
+	defer wordlistsMu.Unlock()
+	registry[wl.Name] = wl
+}
+
+// WordlistByName returns a registered wordlist.
+func WordlistByName(name string) (*Wordlist, bool) {
+	wordlistsMu.RLock()
+	defer wordlistsMu.RUnlock()
+	wl, ok := registry[name]
+	return wl, ok
+}
+
+// DeriveKeysWithWordlist derives account keys for a phrase from any BIP39
+// wordlist. The phrase is NFKD-normalized and may be separated by any
+// whitespace, including U+3000. Only English phrases can be generated by
+// Anytype, but a valid phrase in another language derives its own accounts.
+// A nil wl fails with ErrInvalidMnemonic.
+func DeriveKeysWithWordlist(m string, index int, wl *Wordlist) (*KeyResult, error) {
+	start := time.Now()
+	res, err := deriveKeysWithWordlist(m, index, wl)
+	if err != nil {
+		logDerivation("DeriveKeysWithWordlist", start, err, "index", index)
+		return nil, err
+	}
+	logDerivation("DeriveKeysWithWordlist", start, nil, "index", index, "account_id", res.AccountID)
+	return res, nil
+}
+
+func deriveKeysWithWordlist(m string, index int, wl *Wordlist) (*KeyResult, error) {
+	if wl == nil {
+		return nil, fmt.Errorf("%w: nil wordlist", ErrInvalidMnemonic)
+	}
+	if err := checkIndex(index); err != nil {
+		return nil, err
+	}
+	seed, err := wl.seed(context.Background(), m, "")
+	if err != nil {
+		return nil, err
+	}
+	defer clear(seed)
+	prefixes := seedAccountPrefixes(seed)
+	defer prefixes.wipe()
+	return prefixes.keys(uint32(index)), nil
+}
+
+// seed validates phrase against the list and computes its BIP39 seed from the
+// NFKD-normalized, single-space-joined words and passphrase.
+func (wl *Wordlist) seed(ctx context.Context, phrase, passphrase string) ([]byte, error) {
+	words := strings.Fields(norm.NFKD.String(phrase))
+	entropy, err := wl.entropy(words)
+	clear(entropy)
+	if err != nil {
+		return nil, err
+	}
+	return mnemonicSeed(ctx, strings.Join(words, " "), norm.NFKD.String(passphrase))
+}
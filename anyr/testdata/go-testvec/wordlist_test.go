@@ -0,0 +1,90 @@
+// SPDX-FileCopyrightText: 2025-2026 Steve Schoettler
+// SPDX-License-Identifier: Apache-2.0
+
+package testvec
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/anyproto/go-bip39/wordlists"
+)
+
+// japaneseVector is the first vector of the bip32JP Japanese BIP39 test suite.
+var japaneseVector = struct {
+	mnemonic, passphrase, seed string
+}{
+	mnemonic:   "あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あいこくしん　あおぞら",
+	passphrase: "㍍ガバヴァぱばぐゞちぢ十人十色",
+	seed:       "a262d6fb6122ecf45be09c50492b31f92e9beb7d9a845987a02cefda57a15f9c467a17872029a9e92299b5cbdf306e3a0ee620245cbd508959b6cb7ca637bd55",
+}
+
+func newJapanese(t *testing.T) *Wordlist {
+	t.Helper()
+	ja, err := NewWordlist("japanese", wordlists.Japanese, "　")
+	if err != nil {
+		t.Fatal(err)
+	}
+	return ja
+}
+
+func TestWordlistJapaneseSeed(t *testing.T) {
+	ja := newJapanese(t)
+	seed, err := ja.seed(context.Background(), japaneseVector.mnemonic, japaneseVector.passphrase)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := hex.EncodeToString(seed); got != japaneseVector.seed {
+		t.Fatalf("seed = %s, want %s", got, japaneseVector.seed)
+	}
+}
+
+func TestDeriveKeysWithWordlistJapanese(t *testing.T) {
+	ja := newJapanese(t)
+	RegisterWordlist(ja)
+	if wl, ok := WordlistByName("japanese"); !ok || wl != ja {
+		t.Fatal("japanese wordlist not registered")
+	}
+
+	res, err := DeriveKeysWithWordlist(japaneseVector.mnemonic, 0, ja)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The same words separated by ASCII spaces must derive the same account.
+	ascii, err := DeriveKeysWithWordlist(strings.ReplaceAll(japaneseVector.mnemonic, "　", " "), 0, ja)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AccountID != ascii.AccountID {
+		t.Fatalf("separator changed the account: %s vs %s", res.AccountID, ascii.AccountID)
+	}
+	if _, err := DeriveKeysWithWordlist(japaneseVector.mnemonic, 0, English); !errors.Is(err, ErrMnemonicWord) {
+		t.Fatalf("English list accepted a Japanese phrase: %v", err)
+	}
+}
+
+func TestDeriveKeysWithWordlistEnglish(t *testing.T) {
+	res, err := DeriveKeysWithWordlist(refMnemonic, 0, English)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if res.AccountID != refAccountID {
+		t.Fatalf("account id = %s, want %s", res.AccountID, refAccountID)
+	}
+	if _, err := DeriveKeysWithWordlist(refMnemonic, 0, nil); !errors.Is(err, ErrInvalidMnemonic) {
+		t.Fatalf("DeriveKeysWithWordlist(nil) = %v, want %v", err, ErrInvalidMnemonic)
+	}
+}
+
+func TestNewWordlistRejectsBadLists(t *testing.T) {
+	if _, err := NewWordlist("short", wordlists.English[:2047], " "); err == nil {
+		t.Fatal("accepted a short wordlist")
+	}
+	dup := append([]string{wordlists.English[1]}, wordlists.English[1:]...)
+	if _, err := NewWordlist("dup", dup, " "); err == nil {
+		t.Fatal("accepted a wordlist with duplicates")
+	}
+}